@@ -0,0 +1,12 @@
+-- 003_word_lemma.sql
+-- 单词词元：用于导入与跨词典复用时的去重（Running / running / ran 视为同一词）
+
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS lemma VARCHAR(100) NOT NULL DEFAULT '';
+
+-- 存量数据先以小写形式回填，保证列非空；小写形式不是词元（如 running、studies），
+-- 执行本迁移后必须运行 go run ./internal/cmd/repair-lemmas -conf ./configs 按应用的规则重新计算
+UPDATE words SET lemma = LOWER(TRIM(word)) WHERE lemma = '';
+
+CREATE INDEX IF NOT EXISTS idx_words_dict_lemma ON words(dict_id, lemma);
+CREATE INDEX IF NOT EXISTS idx_words_lemma ON words(lemma);
@@ -0,0 +1,10 @@
+-- 004_word_listing.sql
+-- 单词列表：词频字段与排序/筛选所需索引
+
+-- 词频排名（越小越常用），0 表示未知，由翻译/词库提供方写入
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS frequency INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_words_dict_status ON words(dict_id, status);
+CREATE INDEX IF NOT EXISTS idx_words_dict_next_review ON words(dict_id, next_review_date, id);
+CREATE INDEX IF NOT EXISTS idx_words_dict_ef ON words(dict_id, ef_factor, id);
@@ -0,0 +1,28 @@
+-- 005_word_search.sql
+-- 单词全文检索：tsvector 负责分词匹配与排序，pg_trgm 负责模糊/子串匹配（含中文释义）
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 全文向量：单词权重最高，其次释义，再次音标与例句
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(word, '') || ' ' || COALESCE(lemma, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(meaning, '{}'::jsonb)), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(phonetic, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(example, '')), 'D')
+    ) STORED;
+
+-- 检索文本：小写拼接各字段，释义只取 JSON 中的字符串值，避免匹配到键名
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+        LOWER(
+            COALESCE(word, '') || ' ' ||
+            COALESCE(phonetic, '') || ' ' ||
+            jsonb_path_query_array(COALESCE(meaning, '{}'::jsonb), 'strict $.**?(@.type() == "string")')::text || ' ' ||
+            COALESCE(example, '')
+        )
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_words_search_vector ON words USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_words_search_text_trgm ON words USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_words_lemma_trgm ON words USING GIN (lemma gin_trgm_ops);
@@ -0,0 +1,9 @@
+-- 006_public_dictionaries.sql
+-- 公开词典：其他用户可在目录中检索并克隆到自己的账号
+
+ALTER TABLE dictionaries
+    ADD COLUMN IF NOT EXISTS is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS clone_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_dictionaries_public ON dictionaries(clone_count DESC, created_at DESC)
+    WHERE is_public AND deleted_at IS NULL;
@@ -0,0 +1,19 @@
+-- 007_word_tags.sql
+-- 单词标签：用户自定义，与单词多对多（如按教材单元组织词汇）
+
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS word_tags (
+    word_id BIGINT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (word_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_tags_tag_id ON word_tags(tag_id);
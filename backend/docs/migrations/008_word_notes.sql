@@ -0,0 +1,6 @@
+-- 008_word_notes.sql
+-- 单词的个人笔记（Markdown）与助记，由用户维护，翻译补全时不覆盖
+
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS mnemonic TEXT NOT NULL DEFAULT '';
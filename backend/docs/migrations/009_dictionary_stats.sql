@@ -0,0 +1,32 @@
+-- 009_dictionary_stats.sql
+-- 词典各学习状态的单词数，由单词写操作在同一事务中维护；
+-- total_words / learned_words 同步维护，计数偏差可用 repair-stats 命令修复
+
+ALTER TABLE dictionaries
+    ADD COLUMN IF NOT EXISTS new_words INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS learning_words INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_words INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS mastered_words INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS suspended_words INT NOT NULL DEFAULT 0;
+
+UPDATE dictionaries d
+SET new_words = s.new_words,
+    learning_words = s.learning_words,
+    review_words = s.review_words,
+    mastered_words = s.mastered_words,
+    suspended_words = s.suspended_words,
+    total_words = s.total_words,
+    learned_words = s.learning_words + s.review_words + s.mastered_words
+FROM (
+    SELECT d.id,
+        COUNT(w.id) AS total_words,
+        COUNT(w.id) FILTER (WHERE w.status NOT IN ('learning', 'review', 'mastered', 'suspended') OR w.status IS NULL) AS new_words,
+        COUNT(w.id) FILTER (WHERE w.status = 'learning') AS learning_words,
+        COUNT(w.id) FILTER (WHERE w.status = 'review') AS review_words,
+        COUNT(w.id) FILTER (WHERE w.status = 'mastered') AS mastered_words,
+        COUNT(w.id) FILTER (WHERE w.status = 'suspended') AS suspended_words
+    FROM dictionaries d
+    LEFT JOIN words w ON w.dict_id = d.id
+    GROUP BY d.id
+) s
+WHERE d.id = s.id;
@@ -0,0 +1,5 @@
+-- 010_word_provider.sql
+-- 记录给出释义的翻译提供方，用户手工填写或文件自带的释义为空
+
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS provider VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1,20 @@
+-- 011_lexicon.sql
+-- 全局词库缓存：翻译提供方的查询结果按（规范化单词，提供方）共享给所有用户，
+-- 过期或提供方版本变化后重新查询
+
+CREATE TABLE IF NOT EXISTS lexicon (
+    id BIGSERIAL PRIMARY KEY,
+    word VARCHAR(100) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    version INT NOT NULL DEFAULT 1,
+    phonetic VARCHAR(100) NOT NULL DEFAULT '',
+    meaning JSONB NOT NULL DEFAULT '{}',
+    example TEXT NOT NULL DEFAULT '',
+    frequency INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_lexicon_word_provider UNIQUE (word, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lexicon_provider ON lexicon(provider);
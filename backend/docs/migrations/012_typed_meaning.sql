@@ -0,0 +1,56 @@
+-- 012_typed_meaning.sql
+-- 将释义从旧结构 {"definitions":[{"pos","text"}],"english":[...],"tags":[...]}
+-- 迁移为按词性分组的义项 {"senses":[{"pos","definitions","translations",...}],"tags":[...]}：
+-- definitions 中含汉字的作为 translations，其余与 english 一起作为 definitions
+
+CREATE OR REPLACE FUNCTION pg_temp.typed_meaning(old JSONB) RETURNS JSONB AS $$
+    WITH glosses AS (
+        SELECT g, ord, 'definitions' AS src
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(old->'definitions') = 'array' THEN old->'definitions' ELSE '[]'::jsonb END)
+            WITH ORDINALITY AS t(g, ord)
+        UNION ALL
+        SELECT g, ord + 1000000, 'english'
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(old->'english') = 'array' THEN old->'english' ELSE '[]'::jsonb END)
+            WITH ORDINALITY AS t(g, ord)
+    ),
+    classified AS (
+        SELECT DISTINCT ON (pos, kind, text) pos, kind, text, ord
+        FROM (
+            SELECT COALESCE(TRIM(g->>'pos'), '') AS pos,
+                TRIM(g->>'text') AS text,
+                CASE WHEN src = 'definitions' AND g->>'text' ~ '[\u3400-\u9fff\uf900-\ufaff]'
+                    THEN 'translations' ELSE 'definitions' END AS kind,
+                ord
+            FROM glosses
+            WHERE jsonb_typeof(g) = 'object' AND COALESCE(TRIM(g->>'text'), '') <> ''
+        ) c
+        ORDER BY pos, kind, text, ord
+    ),
+    senses AS (
+        SELECT pos,
+            MIN(ord) AS first,
+            jsonb_agg(text ORDER BY ord) FILTER (WHERE kind = 'definitions') AS definitions,
+            jsonb_agg(text ORDER BY ord) FILTER (WHERE kind = 'translations') AS translations
+        FROM classified
+        GROUP BY pos
+    )
+    SELECT jsonb_strip_nulls(jsonb_build_object(
+        'senses', (
+            SELECT jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+                'pos', NULLIF(pos, ''),
+                'definitions', definitions,
+                'translations', translations
+            )) ORDER BY first)
+            FROM senses
+        ),
+        'tags', CASE WHEN jsonb_typeof(old->'tags') = 'array' AND jsonb_array_length(old->'tags') > 0 THEN old->'tags' END
+    ))
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE words
+SET meaning = pg_temp.typed_meaning(meaning)
+WHERE meaning ? 'definitions' OR meaning ? 'english';
+
+UPDATE lexicon
+SET meaning = pg_temp.typed_meaning(meaning)
+WHERE meaning ? 'definitions' OR meaning ? 'english';
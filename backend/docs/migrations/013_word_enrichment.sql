@@ -0,0 +1,9 @@
+-- 013_word_enrichment.sql
+-- 记录释义来自提供方的哪个结果版本，以及后台补全最近一次尝试的时间；
+-- 已有翻译结果均来自版本 1
+
+ALTER TABLE words
+    ADD COLUMN IF NOT EXISTS provider_version INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS enriched_at TIMESTAMP;
+
+UPDATE words SET provider_version = 1 WHERE provider <> '' AND provider_version = 0;
@@ -0,0 +1,53 @@
+-- 014_word_lemma_unique.sql
+-- 同一词典内按词元唯一，取代只比较原始单词的 UNIQUE(dict_id, word)：
+-- 并发的添加、导入、追加与移动不会再写入 Running 与 run 两条。
+-- 执行前必须已运行 repair-lemmas（见 003_word_lemma.sql），执行后运行 repair-stats 修正词典计数。
+
+BEGIN;
+
+-- 已重复的单词按合并词典的规则只保留记忆进度最领先的一条：
+-- 依次比较学习状态（suspended 与 new 同级）、复习次数、间隔天数与遗忘因子
+CREATE TEMP TABLE lemma_duplicates ON COMMIT DROP AS
+SELECT id AS drop_id, keep_id
+FROM (
+    SELECT id, FIRST_VALUE(id) OVER (
+        PARTITION BY dict_id, lemma
+        ORDER BY CASE status WHEN 'mastered' THEN 3 WHEN 'review' THEN 2 WHEN 'learning' THEN 1 ELSE 0 END DESC,
+            repetitions DESC NULLS LAST, interval DESC NULLS LAST, ef_factor DESC NULLS LAST, id ASC
+    ) AS keep_id
+    FROM words
+) w
+WHERE id <> keep_id;
+
+-- 学习记录与标签转移到保留的单词，保留单词的笔记与助记为空时用重复单词的补上
+UPDATE learn_records r
+SET word_id = d.keep_id
+FROM lemma_duplicates d
+WHERE r.word_id = d.drop_id;
+
+INSERT INTO word_tags (word_id, tag_id, created_at)
+SELECT d.keep_id, t.tag_id, t.created_at
+FROM word_tags t
+JOIN lemma_duplicates d ON d.drop_id = t.word_id
+ON CONFLICT DO NOTHING;
+
+UPDATE words w
+SET notes = CASE WHEN w.notes = '' THEN COALESCE(x.notes, '') ELSE w.notes END,
+    mnemonic = CASE WHEN w.mnemonic = '' THEN COALESCE(x.mnemonic, '') ELSE w.mnemonic END
+FROM (
+    SELECT d.keep_id,
+        (ARRAY_AGG(s.notes ORDER BY s.id) FILTER (WHERE s.notes <> ''))[1] AS notes,
+        (ARRAY_AGG(s.mnemonic ORDER BY s.id) FILTER (WHERE s.mnemonic <> ''))[1] AS mnemonic
+    FROM lemma_duplicates d
+    JOIN words s ON s.id = d.drop_id
+    GROUP BY d.keep_id
+) x
+WHERE w.id = x.keep_id;
+
+DELETE FROM words WHERE id IN (SELECT drop_id FROM lemma_duplicates);
+
+ALTER TABLE words DROP CONSTRAINT IF EXISTS words_dict_id_word_key;
+DROP INDEX IF EXISTS idx_words_dict_lemma;
+ALTER TABLE words ADD CONSTRAINT uq_words_dict_lemma UNIQUE (dict_id, lemma);
+
+COMMIT;
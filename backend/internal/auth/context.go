@@ -5,8 +5,9 @@ import "context"
 type contextKey string
 
 const (
-	userIDKey    contextKey = "auth_user_id"
-	requestIDKey contextKey = "request_id"
+	userIDKey        contextKey = "auth_user_id"
+	requestIDKey     contextKey = "request_id"
+	clientContextKey contextKey = "client_context"
 )
 
 func WithUserID(ctx context.Context, userID int64) context.Context {
@@ -26,3 +27,24 @@ func RequestIDFromContext(ctx context.Context) (string, bool) {
 	requestID, ok := ctx.Value(requestIDKey).(string)
 	return requestID, ok
 }
+
+// WithClientContext 保存未加服务端超时的请求 context（只随客户端断开而取消），
+// 供流式导出、大文件上传等耗时接口摆脱 http.Timeout 的限制
+func WithClientContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clientContextKey, ctx)
+}
+
+// WithoutServerTimeout 返回保留 ctx 中的值、但不受服务端超时影响的 context；
+// 存在 WithClientContext 保存的请求 context 时，客户端断开仍会取消
+func WithoutServerTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	client, ok := ctx.Value(clientContextKey).(context.Context)
+	if !ok {
+		return detached, cancel
+	}
+	stop := context.AfterFunc(client, cancel)
+	return detached, func() {
+		stop()
+		cancel()
+	}
+}
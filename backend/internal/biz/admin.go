@@ -0,0 +1,66 @@
+// internal/biz/admin.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/biz/repo"
+	"backend/internal/conf"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	ErrAdminRequired     = kerrors.Forbidden("ADMIN_REQUIRED", "需要管理员权限")
+	ErrInvalidationScope = kerrors.BadRequest("INVALIDATION_SCOPE_REQUIRED", "请指定单词或提供方，清空全部缓存需传入 all")
+)
+
+// AdminUseCase 管理业务逻辑，管理员由配置中的用户 ID 指定
+type AdminUseCase struct {
+	lexiconRepo repo.LexiconRepo
+	admins      map[int64]bool
+	log         *log.Helper
+}
+
+// NewAdminUseCase 创建管理业务逻辑实例
+func NewAdminUseCase(lexiconRepo repo.LexiconRepo, c *conf.Admin, logger log.Logger) *AdminUseCase {
+	admins := make(map[int64]bool, len(c.GetUserIds()))
+	for _, id := range c.GetUserIds() {
+		admins[id] = true
+	}
+	return &AdminUseCase{
+		lexiconRepo: lexiconRepo,
+		admins:      admins,
+		log:         log.NewHelper(logger),
+	}
+}
+
+// checkAdmin 校验用户是否为管理员
+func (uc *AdminUseCase) checkAdmin(userID int64) error {
+	if !uc.admins[userID] {
+		return ErrAdminRequired
+	}
+	return nil
+}
+
+// InvalidateLexicon 删除全局词库缓存，可按单词和/或提供方限定；all 为 true 时清空全部
+func (uc *AdminUseCase) InvalidateLexicon(ctx context.Context, userID int64, word, provider string, all bool) (int, error) {
+	if err := uc.checkAdmin(userID); err != nil {
+		return 0, err
+	}
+	word = lexiconKey(word)
+	provider = strings.TrimSpace(provider)
+	if word == "" && provider == "" && !all {
+		return 0, ErrInvalidationScope
+	}
+
+	n, err := uc.lexiconRepo.Invalidate(ctx, word, provider)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate lexicon: %w", err)
+	}
+	uc.log.WithContext(ctx).Infof("lexicon invalidated by user_id=%d word=%q provider=%q deleted=%d", userID, word, provider, n)
+	return n, nil
+}
@@ -1,7 +1,12 @@
 package biz
 
 import (
+	"fmt"
+
+	"backend/internal/conf"
 	"backend/pkg/translator"
+
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 )
 
@@ -11,10 +16,74 @@ var ProviderSet = wire.NewSet(
 	NewDictionaryUseCase,
 	NewLearningUseCase,
 	NewAuthUseCase,
+	NewAdminUseCase,
 	ProvideTranslator,
+	NewLexiconPolicy,
+	NewUploadLimits,
+	NewTranslateEstimate,
+	NewEnrichmentPolicy,
 )
 
-// ProvideTranslator 提供翻译器
-func ProvideTranslator() translator.Translator {
-	return translator.NewFreeDictionaryTranslator("")
+// ProvideTranslator 根据配置组装链式翻译器，未配置任何启用的提供方时使用 Free Dictionary
+func ProvideTranslator(c *conf.Translator, logger log.Logger) (translator.Translator, func(), error) {
+	var providers []translator.Provider
+	for _, p := range c.GetProviders() {
+		if !p.GetEnabled() {
+			continue
+		}
+		t, err := newTranslatorProvider(p)
+		if err != nil {
+			translator.NewChain(providers...).Close()
+			return nil, nil, err
+		}
+		providers = append(providers, translator.Provider{
+			Name:       p.GetName(),
+			Translator: t,
+			Breaker:    translator.NewBreaker(int(p.GetFailureThreshold()), p.GetCooldown().AsDuration()),
+		})
+	}
+	if len(providers) == 0 {
+		providers = append(providers, translator.Provider{
+			Name:       translator.ProviderFreeDictionary,
+			Translator: translator.NewFreeDictionaryTranslator(""),
+			Breaker:    translator.NewBreaker(0, 0),
+		})
+	}
+
+	chain := translator.NewChain(providers...)
+	log.NewHelper(logger).Infof("translator providers: %v", chain.Providers())
+	return chain, func() { chain.Close() }, nil
+}
+
+// newTranslatorProvider 按名称创建翻译提供方
+func newTranslatorProvider(c *conf.Translator_Provider) (translator.Translator, error) {
+	opts := []translator.Option{
+		translator.WithTimeout(c.GetTimeout().AsDuration()),
+		translator.WithLimiter(translator.NewLimiter(c.GetRateLimit(), int(c.GetBurst()))),
+	}
+	if c.GetMaxRetries() != 0 {
+		opts = append(opts, translator.WithMaxRetries(int(c.GetMaxRetries())))
+	}
+	switch c.GetName() {
+	case translator.ProviderFreeDictionary:
+		return translator.NewFreeDictionaryTranslator(c.GetBaseUrl(), opts...), nil
+	case translator.ProviderECDICT:
+		return translator.NewECDICTTranslator(c.GetPath())
+	case translator.ProviderStarDict:
+		return translator.NewStarDictTranslator(c.GetPath())
+	default:
+		return nil, fmt.Errorf("unknown translator provider: %q", c.GetName())
+	}
+}
+
+// NewUploadLimits 根据配置提供上传限制，未配置的项使用默认值
+func NewUploadLimits(c *conf.Upload) *UploadLimits {
+	limits := &UploadLimits{MaxBytes: defaultUploadMaxBytes, MaxLines: defaultUploadMaxLines}
+	if c.GetMaxBytes() > 0 {
+		limits.MaxBytes = c.GetMaxBytes()
+	}
+	if c.GetMaxLines() > 0 {
+		limits.MaxLines = int(c.GetMaxLines())
+	}
+	return limits
 }
@@ -2,15 +2,17 @@
 package biz
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"backend/internal/biz/entity"
 	"backend/internal/biz/repo"
+	"backend/pkg/nlp"
 	"backend/pkg/translator"
 
 	kerrors "github.com/go-kratos/kratos/v2/errors"
@@ -18,16 +20,26 @@ import (
 )
 
 var (
-	ErrEmptyWordFile = kerrors.BadRequest("EMPTY_WORD_FILE", "文件中没有可导入的单词")
+	ErrEmptyWordFile       = kerrors.BadRequest("EMPTY_WORD_FILE", "文件中没有可导入的单词")
+	ErrEmptyDictionaryName = kerrors.BadRequest("EMPTY_DICTIONARY_NAME", "词典名称不能为空")
 )
 
 // DictionaryUseCase 词典业务逻辑
 type DictionaryUseCase struct {
-	dictRepo   repo.DictionaryRepo
-	wordRepo   repo.WordRepo
-	taskRepo   repo.UploadTaskRepo
-	translator translator.Translator
-	log        *log.Helper
+	dictRepo    repo.DictionaryRepo
+	wordRepo    repo.WordRepo
+	taskRepo    repo.UploadTaskRepo
+	recordRepo  repo.LearnRecordRepo
+	tagRepo     repo.TagRepo
+	lexiconRepo repo.LexiconRepo
+	translator  translator.Translator
+	lexicon     *LexiconPolicy
+	limits      *UploadLimits
+	estimate    *TranslateEstimate
+	runs        *uploadRuns
+	enrichment  *EnrichmentPolicy
+	enrichRuns  *enrichmentRuns
+	log         *log.Helper
 }
 
 // NewDictionaryUseCase 创建词典业务逻辑实例
@@ -35,15 +47,31 @@ func NewDictionaryUseCase(
 	dictRepo repo.DictionaryRepo,
 	wordRepo repo.WordRepo,
 	taskRepo repo.UploadTaskRepo,
+	recordRepo repo.LearnRecordRepo,
+	tagRepo repo.TagRepo,
+	lexiconRepo repo.LexiconRepo,
 	translator translator.Translator,
+	lexicon *LexiconPolicy,
+	limits *UploadLimits,
+	estimate *TranslateEstimate,
+	enrichment *EnrichmentPolicy,
 	logger log.Logger,
 ) *DictionaryUseCase {
 	return &DictionaryUseCase{
-		dictRepo:   dictRepo,
-		wordRepo:   wordRepo,
-		taskRepo:   taskRepo,
-		translator: translator,
-		log:        log.NewHelper(logger),
+		dictRepo:    dictRepo,
+		wordRepo:    wordRepo,
+		taskRepo:    taskRepo,
+		recordRepo:  recordRepo,
+		tagRepo:     tagRepo,
+		lexiconRepo: lexiconRepo,
+		translator:  translator,
+		lexicon:     lexicon,
+		limits:      limits,
+		estimate:    estimate,
+		runs:        &uploadRuns{byDict: make(map[int64]map[string]context.CancelFunc)},
+		enrichment:  enrichment,
+		enrichRuns:  &enrichmentRuns{byDict: make(map[int64]*EnrichmentRun)},
+		log:         log.NewHelper(logger),
 	}
 }
 
@@ -70,35 +98,133 @@ func (uc *DictionaryUseCase) ListDictionaries(ctx context.Context, userID int64)
 	return uc.dictRepo.ListByUserID(ctx, userID)
 }
 
+// UpdateDictionary 修改词典名称、描述与公开状态，nil 表示不修改
+func (uc *DictionaryUseCase) UpdateDictionary(ctx context.Context, userID, dictID int64, name, description *string, isPublic *bool) (*entity.Dictionary, error) {
+	dict, err := uc.GetDictionaryForUser(ctx, dictID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" {
+			return nil, ErrEmptyDictionaryName
+		}
+		dict.Name = trimmed
+	}
+	if description != nil {
+		dict.Description = strings.TrimSpace(*description)
+	}
+	if isPublic != nil {
+		dict.IsPublic = *isPublic
+	}
+	if err := uc.dictRepo.Update(ctx, dict); err != nil {
+		return nil, fmt.Errorf("failed to update dictionary: %w", err)
+	}
+	return dict, nil
+}
+
+// DeleteDictionary 将词典移入回收站，并取消其未完成的上传任务
+func (uc *DictionaryUseCase) DeleteDictionary(ctx context.Context, userID, dictID int64) error {
+	if _, err := uc.GetDictionaryForUser(ctx, dictID, userID); err != nil {
+		return err
+	}
+	if err := uc.dictRepo.Delete(ctx, dictID); err != nil {
+		return fmt.Errorf("failed to delete dictionary: %w", err)
+	}
+	return uc.cancelUploadTasks(ctx, dictID)
+}
+
 // UploadTaskResult 上传任务结果
 type UploadTaskResult struct {
 	TaskID         string `json:"task_id"`
 	Status         string `json:"status"`
 	TotalWords     int    `json:"total_words"`
 	ProcessedWords int    `json:"processed_words"`
+	ReceivedBytes  int64  `json:"received_bytes"` // 接收到的文件字节数，非文件上传时为 0
+	InvalidLines   int    `json:"invalid_lines"`  // 文件中无法识别为单词而被忽略的行数
 }
 
-// UploadDictionary 上传词典文件
-func (uc *DictionaryUseCase) UploadDictionary(ctx context.Context, reader io.Reader, name, description string, userID int64) (*UploadTaskResult, error) {
+// uploadWord 待导入的单词，Meaning、Example 非空时优先于翻译或复用得到的内容
+type uploadWord struct {
+	Word    string // 规范化后的原始形式
+	Lemma   string // 去重用的词元
+	Meaning string // 文件中附带的释义，非空时不再调用翻译 API
+	Example string
+	Tags    []string // 导入后为单词打上的标签
+	// Fallback 仍查不到释义时使用的手工释义（重试失败单词时由用户补充）
+	Fallback string
+}
+
+// UploadDictionary 上传词典文件，exclude 为预览后取消勾选的单词
+func (uc *DictionaryUseCase) UploadDictionary(ctx context.Context, reader io.Reader, name, description string, userID int64, exclude []string) (*UploadTaskResult, error) {
 	// 1. 解析文件，提取单词列表
-	words, err := uc.parseWordFile(reader)
+	file, err := uc.parseWordFile(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse word file: %w", err)
+	}
+	items, err := excludeUploadWords(file.Items, exclude)
+	if err != nil {
+		return nil, err
+	}
+	result, err := uc.startUploadTask(ctx, name, description, userID, items)
+	if err != nil {
+		return nil, err
+	}
+	result.ReceivedBytes = file.Bytes
+	result.InvalidLines = file.InvalidLines
+	return result, nil
+}
+
+// AppendToDictionary 上传单词文件追加到用户已有的词典，词典中已存在的单词自动跳过
+func (uc *DictionaryUseCase) AppendToDictionary(ctx context.Context, reader io.Reader, dictID, userID int64, exclude []string) (*UploadTaskResult, error) {
+	dict, err := uc.GetDictionaryForUser(ctx, dictID, userID)
+	if err != nil {
+		return nil, err
+	}
+	file, err := uc.parseWordFile(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse word file: %w", err)
 	}
+	items, err := excludeUploadWords(file.Items, exclude)
+	if err != nil {
+		return nil, err
+	}
+	items = normalizeUploadWords(items)
+	if len(items) == 0 {
+		return nil, ErrEmptyWordFile
+	}
+	result, err := uc.runUploadTask(ctx, dict, userID, items)
+	if err != nil {
+		return nil, err
+	}
+	result.ReceivedBytes = file.Bytes
+	result.InvalidLines = file.InvalidLines
+	return result, nil
+}
+
+// startUploadTask 创建词典与上传任务，并异步导入单词
+func (uc *DictionaryUseCase) startUploadTask(ctx context.Context, name, description string, userID int64, items []uploadWord) (*UploadTaskResult, error) {
+	items = normalizeUploadWords(items)
+	if len(items) == 0 {
+		return nil, ErrEmptyWordFile
+	}
 
-	// 2. 创建词典记录
 	dict, err := uc.CreateDictionary(ctx, name, description, userID)
 	if err != nil {
 		return nil, err
 	}
+	return uc.runUploadTask(ctx, dict, userID, items)
+}
 
-	// 3. 创建上传任务
-	taskID := fmt.Sprintf("task_%d_%d", dict.ID, time.Now().Unix())
+// runUploadTask 为目标词典创建上传任务，并异步导入已规范化的单词
+func (uc *DictionaryUseCase) runUploadTask(ctx context.Context, dict *entity.Dictionary, userID int64, items []uploadWord) (*UploadTaskResult, error) {
+	// 同一词典可多次追加上传，任务 ID 使用纳秒时间戳避免冲突
+	taskID := fmt.Sprintf("task_%d_%d", dict.ID, time.Now().UnixNano())
 	task := &entity.UploadTask{
 		ID:            taskID,
 		DictID:        &dict.ID,
 		Status:        "processing",
-		TotalWords:    len(words),
+		TotalWords:    len(items),
 		FailedWords:   []string{},
 		FailedDetails: []entity.FailedDetail{},
 	}
@@ -106,122 +232,209 @@ func (uc *DictionaryUseCase) UploadDictionary(ctx context.Context, reader io.Rea
 		return nil, fmt.Errorf("failed to create upload task: %w", err)
 	}
 
-	// 4. 启动异步任务处理
-	go uc.processUploadTask(taskID, dict.ID, userID, words)
+	// 启动异步任务处理
+	go uc.processUploadTask(taskID, dict.ID, userID, items)
 
 	return &UploadTaskResult{
 		TaskID:         taskID,
 		Status:         "processing",
-		TotalWords:     len(words),
+		TotalWords:     len(items),
 		ProcessedWords: 0,
 	}, nil
 }
 
-// parseWordFile 解析单词文件
-func (uc *DictionaryUseCase) parseWordFile(reader io.Reader) ([]string, error) {
-	var words []string
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			words = append(words, line)
+// normalizeUploadWords 规范化单词并按词元去重，保留首次出现的形式，重复单词的标签合并
+func normalizeUploadWords(items []uploadWord) []uploadWord {
+	result, _ := dedupeUploadWords(items)
+	return result
+}
+
+// dedupeUploadWords 规范化并去重，同时返回被去掉的重复单词（文件中的原始形式）
+func dedupeUploadWords(items []uploadWord) ([]uploadWord, []string) {
+	index := make(map[string]int, len(items))
+	result := make([]uploadWord, 0, len(items))
+	var duplicates []string
+	for _, item := range items {
+		item.Word = nlp.NormalizeSurface(item.Word)
+		item.Lemma = nlp.LemmaKey(item.Word)
+		if item.Lemma == "" {
+			continue
 		}
+		if i, ok := index[item.Lemma]; ok {
+			result[i].Tags = append(result[i].Tags, item.Tags...)
+			duplicates = append(duplicates, item.Word)
+			continue
+		}
+		index[item.Lemma] = len(result)
+		result = append(result, item)
 	}
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	return result, duplicates
+}
+
+// excludeUploadWords 去掉用户在预览中取消勾选的单词（按词元匹配），全部去掉时返回 ErrNoWordsSelected
+func excludeUploadWords(items []uploadWord, exclude []string) ([]uploadWord, error) {
+	if len(exclude) == 0 {
+		return items, nil
 	}
-	if len(words) == 0 {
-		return nil, ErrEmptyWordFile
+	excluded := make(map[string]bool, len(exclude))
+	for _, w := range exclude {
+		excluded[nlp.LemmaKey(w)] = true
 	}
-	return words, nil
+	result := make([]uploadWord, 0, len(items))
+	for _, item := range items {
+		if !excluded[nlp.LemmaKey(item.Word)] {
+			result = append(result, item)
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrNoWordsSelected
+	}
+	return result, nil
 }
 
 // processUploadTask 异步处理上传任务
-func (uc *DictionaryUseCase) processUploadTask(taskID string, dictID, userID int64, words []string) {
+func (uc *DictionaryUseCase) processUploadTask(taskID string, dictID, userID int64, items []uploadWord) {
 	ctx := context.Background()
-	total := len(words)
+	total := len(items)
+
+	// 翻译请求使用可取消的 context，词典被删除时立即中止正在进行和排队中的请求
+	runCtx, finish := uc.runs.start(dictID, taskID)
+	defer finish()
+	wait := &providerWait{}
 
-	// 并发控制：每次最多 5 个并发
-	semaphore := make(chan struct{}, 5)
+	// 导入文件带标签时预先创建标签
+	tagIDsByName := uc.ensureUploadTags(ctx, userID, items)
+
+	// 并发控制：每次最多 uploadConcurrency 个并发
+	semaphore := make(chan struct{}, uploadConcurrency)
 	done := make(chan bool, total)
 
-	for _, wordStr := range words {
-		semaphore <- struct{}{} // 获取信号量
+	started := 0
+	for i, item := range items {
+		// 词典被删除时任务会被取消，定期检查以尽早停止调用翻译 API
+		if runCtx.Err() != nil || (i%cancelCheckInterval == 0 && uc.isTaskCancelled(ctx, taskID)) {
+			uc.log.Infof("upload task cancelled task_id=%s processed=%d total=%d", taskID, i, total)
+			break
+		}
+		select {
+		case semaphore <- struct{}{}: // 获取信号量
+		case <-runCtx.Done():
+			continue
+		}
+		started++
 
-		go func(w string) {
+		go func(item uploadWord) {
 			defer func() { <-semaphore }() // 释放信号量
+			w := item.Word
 
-			// 检查是否已存在
-			existing, _ := uc.wordRepo.GetByDictIDAndWord(ctx, dictID, w)
+			// 检查是否已存在（按词元匹配，Running / running / ran 视为同一词）
+			existing, _ := uc.wordRepo.GetByDictIDAndLemma(ctx, dictID, item.Lemma)
 			if existing != nil {
-				// 已存在，跳过
+				// 已存在，跳过（仍补上标签）
+				uc.tagUploadWord(ctx, existing.ID, item.Tags, tagIDsByName)
 				uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
 				done <- true
 				return
 			}
 
-			// 跨词典复用：若该用户库内已有该词，直接复用释义并跳过 API 请求
-			cachedWord, _ := uc.wordRepo.GetByUserAndWord(ctx, userID, w)
+			// 跨词典复用：若该用户库内已有该词，直接复用释义与个人笔记并跳过 API 请求
+			cachedWord, _ := uc.wordRepo.GetByUserAndLemma(ctx, userID, item.Lemma)
 			if cachedWord != nil {
+				word := &entity.Word{
+					DictID:          dictID,
+					Word:            w,
+					Lemma:           item.Lemma,
+					Phonetic:        cachedWord.Phonetic,
+					Meaning:         cachedWord.Meaning,
+					Example:         pickExample(item.Example, cachedWord.Example),
+					AudioURL:        cachedWord.AudioURL,
+					Frequency:       cachedWord.Frequency,
+					Provider:        cachedWord.Provider,
+					ProviderVersion: cachedWord.ProviderVersion,
+					Notes:           cachedWord.Notes,
+					Mnemonic:        cachedWord.Mnemonic,
+					Status:          "new",
+					EFFactor:        defaultEFFactor,
+				}
+				if item.Meaning != "" {
+					word.Meaning = manualMeaning(item.Meaning)
+					word.Provider = ""
+					word.ProviderVersion = 0
+				}
+				uc.saveUploadWord(ctx, taskID, "reuse", word, item.Tags, tagIDsByName)
+				uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
+				done <- true
+				return
+			}
+
+			// 文件自带释义：直接保存，不调用翻译 API
+			if item.Meaning != "" {
 				word := &entity.Word{
 					DictID:   dictID,
 					Word:     w,
-					Phonetic: cachedWord.Phonetic,
-					Meaning:  cachedWord.Meaning,
-					Example:  cachedWord.Example,
-					AudioURL: cachedWord.AudioURL,
+					Lemma:    item.Lemma,
+					Meaning:  manualMeaning(item.Meaning),
+					Example:  item.Example,
 					Status:   "new",
+					EFFactor: defaultEFFactor,
 				}
-				if err := uc.wordRepo.Create(ctx, word); err != nil {
-					uc.recordUploadFailure(ctx, taskID, w, "reuse", err)
-				}
+				uc.saveUploadWord(ctx, taskID, "save", word, item.Tags, tagIDsByName)
 				uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
 				done <- true
 				return
 			}
 
-			// 调用翻译 API
-			detail, err := uc.translator.Translate(w)
-			if err != nil {
-				// 翻译失败，记录失败单词
-				uc.recordUploadFailure(ctx, taskID, w, "translate", err)
-				uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
+			// 查询全局词库缓存，未命中时调用翻译 API
+			detail, err := uc.translateUpload(runCtx, taskID, w, wait)
+			if runCtx.Err() != nil || errors.Is(err, context.Canceled) {
+				// 任务已取消，不记录为失败
 				done <- true
 				return
 			}
+			if err != nil {
+				if item.Fallback == "" {
+					// 翻译失败，记录失败单词
+					uc.recordUploadFailure(ctx, taskID, w, "translate", err)
+					uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
+					done <- true
+					return
+				}
+				// 仍查不到释义，改用用户补充的手工释义
+				detail = &translator.WordDetail{Meaning: manualMeaning(item.Fallback)}
+			}
 
-			// 保存到数据库
+			// 保存到数据库（保留用户导入时的原始形式用于展示）
 			word := &entity.Word{
-				DictID:   dictID,
-				Word:     detail.Word,
-				Phonetic: detail.Phonetic,
-				Meaning:  detail.Meaning,
-				Example:  detail.Example,
-				Status:   "new",
-			}
-			if err := uc.wordRepo.Create(ctx, word); err != nil {
-				uc.recordUploadFailure(ctx, taskID, w, "save", err)
+				DictID:          dictID,
+				Word:            w,
+				Lemma:           item.Lemma,
+				Phonetic:        detail.Phonetic,
+				Meaning:         detail.Meaning,
+				Example:         pickExample(item.Example, detail.Example),
+				Provider:        detail.Provider,
+				ProviderVersion: uc.lexicon.version(detail.Provider),
+				Frequency:       detail.Frequency,
+				Status:          "new",
+				EFFactor:        defaultEFFactor,
 			}
+			uc.saveUploadWord(ctx, taskID, "save", word, item.Tags, tagIDsByName)
 
 			// 更新进度
 			uc.taskRepo.IncrementProcessed(ctx, taskID, 1)
-
-			// 速率限制：防止 API 限流
-			time.Sleep(100 * time.Millisecond)
 			done <- true
-		}(wordStr)
+		}(item)
 	}
 
-	// 等待所有任务完成
-	for i := 0; i < total; i++ {
+	// 等待所有已启动的任务完成
+	for i := 0; i < started; i++ {
 		<-done
 	}
 
-	// 更新任务状态为完成
+	// 更新任务状态为完成（已取消的任务保持取消状态）
 	task, _ := uc.taskRepo.GetByID(ctx, taskID)
-	if task != nil {
-		// 若全部处理都失败，则标记任务失败，避免前端误判“成功”
-		if total > 0 && len(task.FailedWords) >= total {
+	if task != nil && task.Status != "cancelled" {
+		// 若全部处理都失败，则标记任务失败，避免前端误判“成功”（重试时按任务的单词总数判断）
+		if task.TotalWords > 0 && len(task.FailedWords) >= task.TotalWords {
 			task.Status = "failed"
 		} else {
 			task.Status = "completed"
@@ -229,11 +442,128 @@ func (uc *DictionaryUseCase) processUploadTask(taskID string, dictID, userID int
 		now := time.Now()
 		task.CompletedAt = &now
 		uc.taskRepo.Update(ctx, task)
+	}
+}
+
+// ensureUploadTags 创建导入文件中出现的标签，返回标签名到 ID 的映射；失败时仅记录日志，不影响单词导入
+func (uc *DictionaryUseCase) ensureUploadTags(ctx context.Context, userID int64, items []uploadWord) map[string]int64 {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Tags...)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	names, err := normalizeTags(names)
+	if err != nil {
+		return nil
+	}
+	tags, err := uc.tagRepo.Ensure(ctx, userID, names)
+	if err != nil {
+		uc.log.Warnf("failed to create upload tags user_id=%d err=%v", userID, err)
+		return nil
+	}
+	result := make(map[string]int64, len(tags))
+	for _, t := range tags {
+		result[t.Name] = t.ID
+	}
+	return result
+}
+
+// saveUploadWord 保存导入的单词并打上标签；并发写入了同一词元时视为已存在，只给已有单词补标签
+func (uc *DictionaryUseCase) saveUploadWord(ctx context.Context, taskID, stage string, word *entity.Word, tags []string, tagIDsByName map[string]int64) {
+	err := uc.wordRepo.Create(ctx, word)
+	if errors.Is(err, repo.ErrDuplicateWord) {
+		if existing, _ := uc.wordRepo.GetByDictIDAndLemma(ctx, word.DictID, word.Lemma); existing != nil {
+			uc.tagUploadWord(ctx, existing.ID, tags, tagIDsByName)
+		}
+		return
+	}
+	if err != nil {
+		uc.recordUploadFailure(ctx, taskID, word.Word, stage, err)
+		return
+	}
+	uc.tagUploadWord(ctx, word.ID, tags, tagIDsByName)
+}
+
+// tagUploadWord 为导入的单词打上标签
+func (uc *DictionaryUseCase) tagUploadWord(ctx context.Context, wordID int64, names []string, tagIDsByName map[string]int64) {
+	var ids []int64
+	for _, name := range names {
+		if id, ok := tagIDsByName[NormalizeTag(name)]; ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if _, err := uc.tagRepo.AddWords(ctx, ids, []int64{wordID}); err != nil {
+		uc.log.Warnf("failed to tag uploaded word word_id=%d err=%v", wordID, err)
+	}
+}
+
+// uploadConcurrency 上传任务同时处理的单词数
+const uploadConcurrency = 5
+
+// cancelCheckInterval 上传任务每处理多少个单词检查一次是否已取消
+const cancelCheckInterval = 20
+
+// uploadRuns 本进程内正在运行的上传任务，按词典记录取消函数
+type uploadRuns struct {
+	mu     sync.Mutex
+	byDict map[int64]map[string]context.CancelFunc
+}
+
+// start 登记任务并返回其 context，任务结束时调用 finish 注销
+func (r *uploadRuns) start(dictID int64, taskID string) (ctx context.Context, finish func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byDict[dictID] == nil {
+		r.byDict[dictID] = make(map[string]context.CancelFunc)
+	}
+	r.byDict[dictID][taskID] = cancel
+	return ctx, func() {
+		cancel()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.byDict[dictID], taskID)
+		if len(r.byDict[dictID]) == 0 {
+			delete(r.byDict, dictID)
+		}
+	}
+}
+
+// cancel 取消词典下所有正在运行的任务
+func (r *uploadRuns) cancel(dictID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cancel := range r.byDict[dictID] {
+		cancel()
+	}
+}
+
+// cancelUploadTasks 将词典下未完成的上传任务标记为取消，并中止本进程内正在进行的翻译请求
+func (uc *DictionaryUseCase) cancelUploadTasks(ctx context.Context, dictID int64) error {
+	if err := uc.taskRepo.CancelUnfinishedByDictID(ctx, dictID); err != nil {
+		return fmt.Errorf("failed to cancel upload tasks: %w", err)
+	}
+	uc.runs.cancel(dictID)
+	return nil
+}
+
+// isTaskCancelled 判断上传任务是否已被取消
+func (uc *DictionaryUseCase) isTaskCancelled(ctx context.Context, taskID string) bool {
+	task, err := uc.taskRepo.GetByID(ctx, taskID)
+	return err == nil && task != nil && task.Status == "cancelled"
+}
 
-		// 更新词典统计
-		count, _ := uc.wordRepo.CountByDictID(ctx, dictID)
-		uc.dictRepo.UpdateStats(ctx, dictID, count, 0)
+// pickExample 优先使用导入时自带的例句（如文章原句）
+func pickExample(preferred, fallback string) string {
+	if strings.TrimSpace(preferred) != "" {
+		return preferred
 	}
+	return fallback
 }
 
 func truncateReason(reason string) string {
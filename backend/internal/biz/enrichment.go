@@ -0,0 +1,241 @@
+// internal/biz/enrichment.go
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"backend/internal/biz/entity"
+	"backend/internal/conf"
+	"backend/pkg/translator"
+)
+
+// 后台补全默认配置
+const (
+	defaultEnrichmentInterval   = time.Hour
+	defaultEnrichmentRetryAfter = 24 * time.Hour
+	defaultEnrichmentBatchSize  = 100
+)
+
+// EnrichmentPolicy 后台补全策略
+type EnrichmentPolicy struct {
+	Enabled    bool
+	Interval   time.Duration // 扫描间隔
+	RetryAfter time.Duration // 同一单词两次补全尝试的最小间隔
+	BatchSize  int
+}
+
+// NewEnrichmentPolicy 根据配置生成补全策略，未配置的项使用默认值
+func NewEnrichmentPolicy(c *conf.Enrichment) *EnrichmentPolicy {
+	p := &EnrichmentPolicy{
+		Enabled:    c.GetEnabled(),
+		Interval:   defaultEnrichmentInterval,
+		RetryAfter: defaultEnrichmentRetryAfter,
+		BatchSize:  defaultEnrichmentBatchSize,
+	}
+	if d := c.GetInterval().AsDuration(); d > 0 {
+		p.Interval = d
+	}
+	if d := c.GetRetryAfter().AsDuration(); d > 0 {
+		p.RetryAfter = d
+	}
+	if c.GetBatchSize() > 0 {
+		p.BatchSize = int(c.GetBatchSize())
+	}
+	return p
+}
+
+// EnrichmentRun 一次补全运行的进度
+type EnrichmentRun struct {
+	Running    bool      `json:"running"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Processed  int       `json:"processed"` // 已查询的单词数
+	Enriched   int       `json:"enriched"`  // 补全或刷新了字段的单词数
+	Unchanged  int       `json:"unchanged"` // 无新结果或期间被用户修改而跳过的单词数
+	Failed     int       `json:"failed"`    // 查询出错的单词数
+	LastError  string    `json:"last_error"`
+}
+
+// EnrichmentProgress 词典的补全进度：待补全统计与本进程内最近一次运行
+type EnrichmentProgress struct {
+	DictID int64                   `json:"dict_id"`
+	Stats  *entity.EnrichmentStats `json:"stats"`
+	Run    EnrichmentRun           `json:"run"`
+}
+
+// enrichmentRuns 本进程内各词典最近一次补全运行，同一词典同时只运行一次
+type enrichmentRuns struct {
+	mu     sync.Mutex
+	byDict map[int64]*EnrichmentRun
+}
+
+// start 登记新的运行，该词典已有运行中的任务时返回 nil
+func (r *enrichmentRuns) start(dictID int64) *EnrichmentRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run := r.byDict[dictID]; run != nil && run.Running {
+		return nil
+	}
+	run := &EnrichmentRun{Running: true, StartedAt: time.Now()}
+	r.byDict[dictID] = run
+	return run
+}
+
+// update 在锁内修改运行进度
+func (r *enrichmentRuns) update(run *EnrichmentRun, fn func(*EnrichmentRun)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(run)
+}
+
+// get 返回词典最近一次运行的快照
+func (r *enrichmentRuns) get(dictID int64) EnrichmentRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run := r.byDict[dictID]; run != nil {
+		return *run
+	}
+	return EnrichmentRun{}
+}
+
+// GetEnrichmentProgress 查询词典的补全进度
+func (uc *DictionaryUseCase) GetEnrichmentProgress(ctx context.Context, userID, dictID int64) (*EnrichmentProgress, error) {
+	if _, err := uc.GetDictionaryForUser(ctx, dictID, userID); err != nil {
+		return nil, err
+	}
+	stats, err := uc.wordRepo.CountEnrichment(ctx, dictID, uc.lexicon.Sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count enrichment: %w", err)
+	}
+	return &EnrichmentProgress{DictID: dictID, Stats: stats, Run: uc.enrichRuns.get(dictID)}, nil
+}
+
+// EnrichDictionary 立即在后台补全词典，已在运行时直接返回当前进度
+func (uc *DictionaryUseCase) EnrichDictionary(ctx context.Context, userID, dictID int64) (*EnrichmentProgress, error) {
+	if _, err := uc.GetDictionaryForUser(ctx, dictID, userID); err != nil {
+		return nil, err
+	}
+	if run := uc.enrichRuns.start(dictID); run != nil {
+		go uc.runEnrichment(context.Background(), dictID, run)
+	}
+	return uc.GetEnrichmentProgress(ctx, userID, dictID)
+}
+
+// RunEnrichment 补全所有存在待补全单词的词典，供后台定时任务调用。
+// 翻译服务不可用时提前结束，等待下一轮。
+func (uc *DictionaryUseCase) RunEnrichment(ctx context.Context) error {
+	retryBefore := time.Now().Add(-uc.enrichment.RetryAfter)
+	dictIDs, err := uc.wordRepo.ListEnrichmentDictIDs(ctx, uc.lexicon.Sources, retryBefore)
+	if err != nil {
+		return fmt.Errorf("failed to list enrichment dictionaries: %w", err)
+	}
+	for _, dictID := range dictIDs {
+		run := uc.enrichRuns.start(dictID)
+		if run == nil {
+			continue
+		}
+		if err := uc.runEnrichment(ctx, dictID, run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runEnrichment 分批补全词典中的单词并更新运行进度
+func (uc *DictionaryUseCase) runEnrichment(ctx context.Context, dictID int64, run *EnrichmentRun) (err error) {
+	defer func() {
+		uc.enrichRuns.update(run, func(r *EnrichmentRun) {
+			r.Running = false
+			r.FinishedAt = time.Now()
+			if err != nil {
+				r.LastError = err.Error()
+			}
+		})
+		uc.log.WithContext(ctx).Infof("enrichment finished dict=%d processed=%d enriched=%d unchanged=%d failed=%d err=%v",
+			dictID, run.Processed, run.Enriched, run.Unchanged, run.Failed, err)
+	}()
+
+	retryBefore := run.StartedAt.Add(-uc.enrichment.RetryAfter)
+	var afterID int64
+	for {
+		words, err := uc.wordRepo.ListEnrichmentCandidates(ctx, dictID, uc.lexicon.Sources, retryBefore, afterID, uc.enrichment.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list enrichment candidates: %w", err)
+		}
+		if len(words) == 0 {
+			return nil
+		}
+		for _, word := range words {
+			afterID = word.ID
+			enriched, err := uc.enrichWord(ctx, word)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, translator.ErrUnavailable) {
+				return err
+			}
+			uc.enrichRuns.update(run, func(r *EnrichmentRun) {
+				r.Processed++
+				switch {
+				case err != nil:
+					r.Failed++
+					r.LastError = fmt.Sprintf("%s: %v", word.Word, err)
+				case enriched:
+					r.Enriched++
+				default:
+					r.Unchanged++
+				}
+			})
+		}
+	}
+}
+
+// enrichWord 重新查询单词并合并翻译器给出的字段，返回是否有字段被更新。
+// 查不到释义时只记录尝试时间；其他错误不记录，下一轮重试。
+func (uc *DictionaryUseCase) enrichWord(ctx context.Context, word *entity.Word) (bool, error) {
+	detail, err := uc.translate(ctx, word.Word)
+	if errors.Is(err, translator.ErrNotFound) {
+		return false, uc.wordRepo.MarkEnrichmentAttempted(ctx, word.ID)
+	}
+	if err != nil {
+		return false, err
+	}
+	readAt := word.UpdatedAt
+	if !mergeEnrichment(word, detail, uc.lexicon.version(detail.Provider)) {
+		return false, uc.wordRepo.MarkEnrichmentAttempted(ctx, word.ID)
+	}
+	return uc.wordRepo.UpdateEnrichment(ctx, word, readAt)
+}
+
+// mergeEnrichment 将查询结果合并到单词，返回是否有变化。
+// 释义只在来自翻译提供方（非手工填写）或为空时替换；音标、例句、词频只填补空值，
+// 不修改笔记、助记与记忆状态。
+func mergeEnrichment(word *entity.Word, detail *translator.WordDetail, version int) bool {
+	changed := false
+	if (word.Provider != "" || word.Meaning.IsEmpty()) && !detail.Meaning.IsEmpty() {
+		if word.Provider != detail.Provider || word.ProviderVersion != version || !reflect.DeepEqual(word.Meaning, detail.Meaning) {
+			word.Meaning = detail.Meaning
+			word.Provider = detail.Provider
+			word.ProviderVersion = version
+			changed = true
+		}
+	}
+	if word.Phonetic == "" && detail.Phonetic != "" {
+		word.Phonetic = detail.Phonetic
+		changed = true
+	}
+	if word.Example == "" && detail.Example != "" {
+		word.Example = detail.Example
+		changed = true
+	}
+	if word.Frequency == 0 && detail.Frequency > 0 {
+		word.Frequency = detail.Frequency
+		changed = true
+	}
+	return changed
+}
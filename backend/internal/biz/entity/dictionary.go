@@ -3,18 +3,39 @@ package entity
 
 import (
 	"time"
+
+	"backend/pkg/meaning"
 )
 
 // Dictionary 词典实体
 type Dictionary struct {
-	ID           int64     `json:"id" db:"id"`
-	UserID       int64     `json:"user_id" db:"user_id"`
-	Name         string    `json:"name" db:"name"`
-	Description  string    `json:"description" db:"description"`
-	TotalWords   int       `json:"total_words" db:"total_words"`
-	LearnedWords int       `json:"learned_words" db:"learned_words"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           int64      `json:"id" db:"id"`
+	UserID       int64      `json:"user_id" db:"user_id"`
+	Name         string     `json:"name" db:"name"`
+	Description  string     `json:"description" db:"description"`
+	TotalWords   int        `json:"total_words" db:"total_words"`
+	LearnedWords int        `json:"learned_words" db:"learned_words"` // learning + review + mastered
+	Stats        DictStats  `json:"stats"`
+	IsPublic     bool       `json:"is_public" db:"is_public"`     // 是否出现在公开词典目录中
+	CloneCount   int        `json:"clone_count" db:"clone_count"` // 被其他用户克隆的次数
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// DictStats 词典各学习状态的单词数，随单词写入在同一事务中维护
+type DictStats struct {
+	New       int `json:"new" db:"new_words"`
+	Learning  int `json:"learning" db:"learning_words"`
+	Review    int `json:"review" db:"review_words"`
+	Mastered  int `json:"mastered" db:"mastered_words"`
+	Suspended int `json:"suspended" db:"suspended_words"`
+}
+
+// PublicDictionary 公开词典目录项
+type PublicDictionary struct {
+	*Dictionary
+	OwnerName string `json:"owner_name"`
 }
 
 // Progress 计算学习进度
@@ -27,21 +48,88 @@ func (d *Dictionary) Progress() float64 {
 
 // Word 单词实体
 type Word struct {
-	ID             int64                  `json:"id" db:"id"`
-	DictID         int64                  `json:"dict_id" db:"dict_id"`
-	Word           string                 `json:"word" db:"word"`
-	Phonetic       string                 `json:"phonetic" db:"phonetic"`
-	Meaning        map[string]interface{} `json:"meaning" db:"meaning"`
-	Example        string                 `json:"example" db:"example"`
-	AudioURL       string                 `json:"audio_url" db:"audio_url"`
-	Status         string                 `json:"status" db:"status"`           // new/learning/review/mastered
-	EFFactor       float64                `json:"ef_factor" db:"ef_factor"`     // 遗忘因子
-	Interval       int                    `json:"interval" db:"interval"`       // 间隔天数
-	Repetitions    int                    `json:"repetitions" db:"repetitions"` // 已复习次数
-	NextReviewDate *time.Time             `json:"next_review_date" db:"next_review_date"`
-	LastReviewDate *time.Time             `json:"last_review_date" db:"last_review_date"`
-	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at" db:"updated_at"`
+	ID              int64           `json:"id" db:"id"`
+	DictID          int64           `json:"dict_id" db:"dict_id"`
+	Word            string          `json:"word" db:"word"`   // 原始形式，用于展示
+	Lemma           string          `json:"lemma" db:"lemma"` // 规范化词元，用于去重
+	Phonetic        string          `json:"phonetic" db:"phonetic"`
+	Meaning         meaning.Meaning `json:"meaning" db:"meaning"`
+	Example         string          `json:"example" db:"example"`
+	AudioURL        string          `json:"audio_url" db:"audio_url"`
+	Frequency       int             `json:"frequency" db:"frequency"`               // 词频排名，越小越常用，0 表示未知
+	Provider        string          `json:"provider" db:"provider"`                 // 给出释义的翻译提供方，手工释义为空
+	ProviderVersion int             `json:"provider_version" db:"provider_version"` // 释义对应的提供方结果版本
+	Notes           string          `json:"notes" db:"notes"`                       // 个人笔记（Markdown），翻译补全不覆盖
+	Mnemonic        string          `json:"mnemonic" db:"mnemonic"`                 // 助记，翻译补全不覆盖
+	Status          string          `json:"status" db:"status"`                     // new/learning/review/mastered/suspended
+	EFFactor        float64         `json:"ef_factor" db:"ef_factor"`               // 遗忘因子
+	Interval        int             `json:"interval" db:"interval"`                 // 间隔天数
+	Repetitions     int             `json:"repetitions" db:"repetitions"`           // 已复习次数
+	NextReviewDate  *time.Time      `json:"next_review_date" db:"next_review_date"`
+	LastReviewDate  *time.Time      `json:"last_review_date" db:"last_review_date"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+	Tags            []string        `json:"tags,omitempty" db:"-"` // 标签名，仅列表查询时填充
+}
+
+// Tag 用户自定义的单词标签
+type Tag struct {
+	ID        int64     `json:"id" db:"id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	WordCount int       `json:"word_count" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// WordSort 单词列表排序方式
+type WordSort string
+
+const (
+	WordSortCreated    WordSort = "created"     // 添加顺序（默认）
+	WordSortAlpha      WordSort = "alpha"       // 按词元字母序
+	WordSortNextReview WordSort = "next_review" // 按下次复习日期，未安排复习的排在最后
+	WordSortEFFactor   WordSort = "ef"          // 按遗忘因子
+	WordSortFrequency  WordSort = "frequency"   // 按词频排名，未知词频的排在最后
+)
+
+// WordFilter 单词列表查询条件，零值字段表示不过滤
+type WordFilter struct {
+	DictID          int64
+	Statuses        []string
+	MinEF           *float64
+	MaxEF           *float64
+	DueBefore       *time.Time // 下次复习日期早于等于该日期
+	DueAfter        *time.Time // 下次复习日期晚于等于该日期
+	Prefix          string     // 词元前缀
+	HasFailedLookup *bool      // 是否缺少释义（翻译失败或尚未补全）
+	Tag             string     // 标签名
+	Sort            WordSort
+	Descending      bool
+	// 游标分页：上一页最后一条记录的排序键与 ID
+	AfterKey string
+	AfterID  int64
+	Limit    int
+}
+
+// WordSearchQuery 单词全文检索条件
+type WordSearchQuery struct {
+	TSQuery string // to_tsquery 语法的前缀查询，如 "give:* & up:*"
+	Term    string // 规范化后的原始检索词，用于子串与模糊匹配
+	Limit   int
+	Offset  int
+}
+
+// WordSearchHit 单词检索命中结果
+type WordSearchHit struct {
+	Word     *Word
+	DictName string
+	Score    float64
+}
+
+// WordExport 单词导出记录，可选附带完整学习记录
+type WordExport struct {
+	*Word
+	LearnRecords []*LearnRecord `json:"learn_records,omitempty"`
 }
 
 // LearnRecord 学习记录实体
@@ -57,11 +145,40 @@ type LearnRecord struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 }
 
+// LexiconEntry 全局词库缓存项：翻译提供方对某个单词的查询结果，所有用户共享
+type LexiconEntry struct {
+	ID        int64           `json:"id" db:"id"`
+	Word      string          `json:"word" db:"word"` // 规范化（小写）单词
+	Provider  string          `json:"provider" db:"provider"`
+	Version   int             `json:"version" db:"version"` // 写入时提供方的配置版本，版本变化后旧缓存失效
+	Phonetic  string          `json:"phonetic" db:"phonetic"`
+	Meaning   meaning.Meaning `json:"meaning" db:"meaning"`
+	Example   string          `json:"example" db:"example"`
+	Frequency int             `json:"frequency" db:"frequency"`
+	ExpiresAt time.Time       `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// LexiconSource 缓存查询条件中的提供方及其当前版本
+type LexiconSource struct {
+	Provider string
+	Version  int
+}
+
+// EnrichmentStats 词典中待补全的单词统计
+type EnrichmentStats struct {
+	TotalWords        int // 单词总数
+	MissingWords      int // 缺少音标、例句或释义的单词
+	FailedLookupWords int // 没有任何释义的单词（查询失败）
+	StaleWords        int // 提供方版本已过期的单词
+}
+
 // UploadTask 上传任务实体
 type UploadTask struct {
 	ID             string         `json:"id" db:"id"`
 	DictID         *int64         `json:"dict_id" db:"dict_id"`
-	Status         string         `json:"status" db:"status"` // pending/processing/completed/failed
+	Status         string         `json:"status" db:"status"` // pending/processing/waiting_provider/completed/failed/cancelled
 	TotalWords     int            `json:"total_words" db:"total_words"`
 	ProcessedWords int            `json:"processed_words" db:"processed_words"`
 	FailedWords    []string       `json:"failed_words" db:"failed_words"`
@@ -0,0 +1,226 @@
+// internal/biz/export.go
+package biz
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/biz/entity"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrUnsupportedExportFormat = kerrors.BadRequest("UNSUPPORTED_EXPORT_FORMAT", "不支持的导出格式，仅支持 jsonl 或 csv")
+)
+
+// ExportFormat 词典导出格式
+type ExportFormat string
+
+const (
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatCSV   ExportFormat = "csv"
+)
+
+// exportFlushEvery 导出时每写出多少行刷新一次缓冲
+const exportFlushEvery = 200
+
+// exportBatchSize 导出学习记录时每批加载的单词数
+const exportBatchSize = 200
+
+// ParseExportFormat 解析导出格式，空值默认为 jsonl
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch ExportFormat(strings.ToLower(strings.TrimSpace(format))) {
+	case "", ExportFormatJSONL, "json", "ndjson":
+		return ExportFormatJSONL, nil
+	case ExportFormatCSV:
+		return ExportFormatCSV, nil
+	default:
+		return "", ErrUnsupportedExportFormat
+	}
+}
+
+// ContentType 返回导出格式对应的 MIME 类型
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson; charset=utf-8"
+}
+
+// wordCSVHeader CSV 导出表头，与 wordCSVRecord 的列顺序保持一致
+var wordCSVHeader = []string{
+	"id", "dict_id", "word", "lemma", "phonetic", "meaning", "example", "audio_url",
+	"frequency", "provider", "provider_version", "notes", "mnemonic", "status", "ef_factor", "interval", "repetitions", "next_review_date", "last_review_date",
+	"created_at", "updated_at",
+}
+
+// GetDictionaryForUser 获取属于该用户的词典
+func (uc *DictionaryUseCase) GetDictionaryForUser(ctx context.Context, dictID, userID int64) (*entity.Dictionary, error) {
+	owned, err := uc.dictRepo.IsOwnedByUser(ctx, dictID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify dictionary ownership: %w", err)
+	}
+	if !owned {
+		return nil, ErrUnauthorized
+	}
+	return uc.dictRepo.GetByID(ctx, dictID)
+}
+
+// ExportDictionary 以流式方式导出词典单词（含 SM-2 记忆状态），调用方需先校验词典归属
+func (uc *DictionaryUseCase) ExportDictionary(ctx context.Context, dict *entity.Dictionary, format ExportFormat, includeRecords bool, w io.Writer) error {
+	switch format {
+	case ExportFormatJSONL:
+		return uc.exportJSONL(ctx, dict.ID, includeRecords, w)
+	case ExportFormatCSV:
+		return uc.exportCSV(ctx, dict.ID, includeRecords, w)
+	default:
+		return ErrUnsupportedExportFormat
+	}
+}
+
+// exportJSONL 每行输出一个 JSON 对象
+func (uc *DictionaryUseCase) exportJSONL(ctx context.Context, dictID int64, includeRecords bool, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	rows := 0
+	err := uc.streamWordExports(ctx, dictID, includeRecords, func(record *entity.WordExport) error {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to write export record: %w", err)
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			flushWriter(w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	flushWriter(w)
+	return nil
+}
+
+// exportCSV 输出 CSV，释义与学习记录以 JSON 字符串形式写入单元格
+func (uc *DictionaryUseCase) exportCSV(ctx context.Context, dictID int64, includeRecords bool, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := wordCSVHeader
+	if includeRecords {
+		header = append(append([]string{}, wordCSVHeader...), "learn_records")
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	rows := 0
+	err := uc.streamWordExports(ctx, dictID, includeRecords, func(record *entity.WordExport) error {
+		line := wordCSVRecord(record.Word)
+		if includeRecords {
+			recordsJSON, _ := json.Marshal(record.LearnRecords)
+			line = append(line, string(recordsJSON))
+		}
+		if err := cw.Write(line); err != nil {
+			return fmt.Errorf("failed to write export record: %w", err)
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			cw.Flush()
+			flushWriter(w)
+			return cw.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	flushWriter(w)
+	return cw.Error()
+}
+
+// streamWordExports 分批遍历词典单词，需要学习记录时每批只查询一次，再逐条交给 fn
+func (uc *DictionaryUseCase) streamWordExports(ctx context.Context, dictID int64, includeRecords bool, fn func(*entity.WordExport) error) error {
+	batch := make([]*entity.Word, 0, exportBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		var records map[int64][]*entity.LearnRecord
+		if includeRecords {
+			ids := make([]int64, 0, len(batch))
+			for _, word := range batch {
+				ids = append(ids, word.ID)
+			}
+			var err error
+			if records, err = uc.recordRepo.ListByWordIDs(ctx, ids); err != nil {
+				return fmt.Errorf("failed to list learn records: %w", err)
+			}
+		}
+		for _, word := range batch {
+			if err := fn(&entity.WordExport{Word: word, LearnRecords: records[word.ID]}); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err := uc.wordRepo.StreamByDictID(ctx, dictID, func(word *entity.Word) error {
+		batch = append(batch, word)
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func wordCSVRecord(w *entity.Word) []string {
+	meaningJSON, _ := json.Marshal(w.Meaning)
+	return []string{
+		strconv.FormatInt(w.ID, 10),
+		strconv.FormatInt(w.DictID, 10),
+		w.Word,
+		w.Lemma,
+		w.Phonetic,
+		string(meaningJSON),
+		w.Example,
+		w.AudioURL,
+		strconv.Itoa(w.Frequency),
+		w.Provider,
+		strconv.Itoa(w.ProviderVersion),
+		w.Notes,
+		w.Mnemonic,
+		w.Status,
+		strconv.FormatFloat(w.EFFactor, 'f', 2, 64),
+		strconv.Itoa(w.Interval),
+		strconv.Itoa(w.Repetitions),
+		formatExportDate(w.NextReviewDate),
+		formatExportDate(w.LastReviewDate),
+		w.CreatedAt.Format(time.RFC3339),
+		w.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatExportDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// flushWriter 若底层 Writer 支持（如 http.ResponseWriter），立即把已写出的数据推送给客户端
+func flushWriter(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
@@ -38,8 +38,8 @@ type TodayTasksResult struct {
 	Words       []*entity.Word `json:"words"`
 }
 
-// GetTodayTasks 获取今日学习任务
-func (uc *LearningUseCase) GetTodayTasks(ctx context.Context, userID, dictID int64, limit int) (*TodayTasksResult, error) {
+// GetTodayTasks 获取今日学习任务，tag 非空时只学习带该标签的单词
+func (uc *LearningUseCase) GetTodayTasks(ctx context.Context, userID, dictID int64, tag string, limit int) (*TodayTasksResult, error) {
 	owned, err := uc.dictRepo.IsOwnedByUser(ctx, dictID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify dictionary ownership: %w", err)
@@ -49,19 +49,19 @@ func (uc *LearningUseCase) GetTodayTasks(ctx context.Context, userID, dictID int
 	}
 
 	// 1. 获取今日待复习数
-	reviewCount, err := uc.wordRepo.CountReviewToday(ctx, dictID)
+	reviewCount, err := uc.wordRepo.CountReviewToday(ctx, dictID, tag)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count review tasks: %w", err)
 	}
 
 	// 2. 获取新词数
-	newCount, err := uc.wordRepo.CountNewWords(ctx, dictID)
+	newCount, err := uc.wordRepo.CountNewWords(ctx, dictID, tag)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count new words: %w", err)
 	}
 
 	// 3. 获取任务队列
-	words, err := uc.wordRepo.GetTodayTasks(ctx, dictID, limit)
+	words, err := uc.wordRepo.GetTodayTasks(ctx, dictID, tag, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get today tasks: %w", err)
 	}
@@ -113,10 +113,12 @@ func (uc *LearningUseCase) SubmitLearning(ctx context.Context, userID, wordID in
 	now := time.Now()
 	word.LastReviewDate = &now
 
-	// 更新状态
-	word.Status = algorithm.GetWordStatus(result.Interval, result.Repetitions)
+	// 更新状态，暂停的单词保持暂停
+	if word.Status != "suspended" {
+		word.Status = algorithm.GetWordStatus(result.Interval, result.Repetitions)
+	}
 
-	// 5. 保存更新
+	// 5. 保存更新（词典各状态计数在同一事务中维护）
 	if err := uc.wordRepo.Update(ctx, word); err != nil {
 		return nil, fmt.Errorf("failed to update word: %w", err)
 	}
@@ -135,11 +137,6 @@ func (uc *LearningUseCase) SubmitLearning(ctx context.Context, userID, wordID in
 		return nil, fmt.Errorf("failed to create learn record: %w", err)
 	}
 
-	// 7. 更新词典统计（如果单词首次变为已学习状态）
-	if word.Status != "new" && oldInterval == 0 {
-		uc.updateDictionaryStats(ctx, word.DictID)
-	}
-
 	return &SubmitResult{
 		WordID:         wordID,
 		NewStatus:      word.Status,
@@ -148,9 +145,3 @@ func (uc *LearningUseCase) SubmitLearning(ctx context.Context, userID, wordID in
 		EFFactor:       result.EFactor,
 	}, nil
 }
-
-// updateDictionaryStats 更新词典统计
-func (uc *LearningUseCase) updateDictionaryStats(ctx context.Context, dictID int64) {
-	// 这里可以添加更新词典学习进度的逻辑
-	// 例如：增加 learned_words 计数
-}
@@ -0,0 +1,108 @@
+// internal/biz/lexicon.go
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend/internal/biz/entity"
+	"backend/internal/conf"
+	"backend/pkg/nlp"
+	"backend/pkg/translator"
+)
+
+// defaultLexiconTTL 全局词库缓存默认有效期
+const defaultLexiconTTL = 30 * 24 * time.Hour
+
+// LexiconPolicy 全局词库缓存策略
+type LexiconPolicy struct {
+	TTL     time.Duration
+	Sources []entity.LexiconSource // 启用的提供方及其当前版本，按优先级排列
+}
+
+// NewLexiconPolicy 根据翻译配置生成缓存策略，提供方顺序与链式翻译器一致
+func NewLexiconPolicy(c *conf.Translator) *LexiconPolicy {
+	p := &LexiconPolicy{TTL: defaultLexiconTTL}
+	if ttl := c.GetCacheTtl().AsDuration(); ttl > 0 {
+		p.TTL = ttl
+	}
+	for _, provider := range c.GetProviders() {
+		if provider.GetEnabled() {
+			p.Sources = append(p.Sources, entity.LexiconSource{
+				Provider: provider.GetName(),
+				Version:  lexiconVersion(provider.GetVersion()),
+			})
+		}
+	}
+	if len(p.Sources) == 0 {
+		p.Sources = []entity.LexiconSource{{Provider: translator.ProviderFreeDictionary, Version: 1}}
+	}
+	return p
+}
+
+// version 返回提供方当前的结果版本，手工释义（provider 为空）没有版本
+func (p *LexiconPolicy) version(provider string) int {
+	if provider == "" {
+		return 0
+	}
+	for _, s := range p.Sources {
+		if s.Provider == provider {
+			return s.Version
+		}
+	}
+	return 1
+}
+
+func lexiconVersion(v int32) int {
+	if v <= 0 {
+		return 1
+	}
+	return int(v)
+}
+
+// lexiconKey 全局词库缓存键：规范化并转为小写的单词
+func lexiconKey(word string) string {
+	return strings.ToLower(nlp.NormalizeSurface(word))
+}
+
+// translate 查询单词释义：先查所有用户共享的全局词库缓存，未命中时调用翻译器并写回缓存。
+// 缓存读写失败只记录日志，不影响翻译。
+func (uc *DictionaryUseCase) translate(ctx context.Context, word string) (*translator.WordDetail, error) {
+	key := lexiconKey(word)
+	entry, err := uc.lexiconRepo.Get(ctx, key, uc.lexicon.Sources)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("failed to read lexicon word=%q: %v", key, err)
+	}
+	if entry != nil {
+		return &translator.WordDetail{
+			Word:      entry.Word,
+			Phonetic:  entry.Phonetic,
+			Meaning:   entry.Meaning,
+			Example:   entry.Example,
+			Provider:  entry.Provider,
+			Frequency: entry.Frequency,
+		}, nil
+	}
+
+	detail, err := uc.translator.Translate(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Provider != "" {
+		entry := &entity.LexiconEntry{
+			Word:      key,
+			Provider:  detail.Provider,
+			Version:   uc.lexicon.version(detail.Provider),
+			Phonetic:  detail.Phonetic,
+			Meaning:   detail.Meaning,
+			Example:   detail.Example,
+			Frequency: detail.Frequency,
+			ExpiresAt: time.Now().Add(uc.lexicon.TTL),
+		}
+		if err := uc.lexiconRepo.Put(ctx, entry); err != nil {
+			uc.log.WithContext(ctx).Warnf("failed to write lexicon word=%q provider=%s: %v", key, detail.Provider, err)
+		}
+	}
+	return detail, nil
+}
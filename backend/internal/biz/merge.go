@@ -0,0 +1,297 @@
+// internal/biz/merge.go
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/internal/biz/entity"
+	"backend/internal/biz/repo"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrNoSourceDictionaries = kerrors.BadRequest("NO_SOURCE_DICTIONARIES", "请选择要合并的词典")
+	ErrInvalidSplitMode     = kerrors.BadRequest("INVALID_SPLIT_MODE", "请指定每组单词数或筛选条件")
+	ErrTooManySplitParts    = kerrors.BadRequest("TOO_MANY_SPLIT_PARTS", "拆分后的词典数量过多")
+	ErrNothingToSplit       = kerrors.BadRequest("NOTHING_TO_SPLIT", "没有需要拆分出的单词")
+)
+
+const (
+	// maxSplitParts 按数量拆分时最多生成的新词典数
+	maxSplitParts = 100
+	// splitPageSize 拆分时分批读取单词的条数
+	splitPageSize = 500
+)
+
+// statusRank 学习状态的先后顺序，用于比较记忆进度；
+// 暂停学习（suspended）的单词与 new 同级，再按复习次数等比较
+var statusRank = map[string]int{"suspended": 0, "new": 0, "learning": 1, "review": 2, "mastered": 3}
+
+// MergeInput 合并词典参数
+type MergeInput struct {
+	SourceDictIDs []int64
+	TargetDictID  int64 // 为 0 时以 Name/Description 新建目标词典
+	Name          string
+	Description   string
+}
+
+// MergeResult 合并结果
+type MergeResult struct {
+	Dictionary     *entity.Dictionary
+	Moved          int     // 直接移入目标词典的单词数
+	Deduplicated   int     // 与目标词典重复而合并的单词数
+	DeletedDictIDs []int64 // 已移入回收站的源词典
+}
+
+// SplitInput 拆分词典参数，ChunkSize 与 Filter 二选一
+type SplitInput struct {
+	DictID    int64
+	ChunkSize int                // 每 N 个单词拆分为一个词典，源词典保留前 N 个
+	Filter    *entity.WordFilter // 匹配条件的单词移入一个新词典
+	Name      string             // 新词典名称，为空时沿用源词典名称并编号
+}
+
+// SplitResult 拆分结果
+type SplitResult struct {
+	Dictionaries []*entity.Dictionary
+	Moved        int
+}
+
+// moreAdvanced 判断 a 的 SM-2 记忆进度是否领先于 b
+// 依次比较学习状态、复习次数、间隔天数与遗忘因子
+func moreAdvanced(a, b *entity.Word) bool {
+	if ra, rb := statusRank[a.Status], statusRank[b.Status]; ra != rb {
+		return ra > rb
+	}
+	if a.Repetitions != b.Repetitions {
+		return a.Repetitions > b.Repetitions
+	}
+	if a.Interval != b.Interval {
+		return a.Interval > b.Interval
+	}
+	return a.EFFactor > b.EFFactor
+}
+
+// MergeDictionaries 将多个词典合并到目标词典
+// 同一词元只保留一条，保留记忆进度更领先的单词，另一条的学习记录转移到保留的单词上；
+// 合并后的源词典移入回收站
+func (uc *DictionaryUseCase) MergeDictionaries(ctx context.Context, userID int64, in *MergeInput) (*MergeResult, error) {
+	sources := make([]int64, 0, len(in.SourceDictIDs))
+	seen := map[int64]bool{in.TargetDictID: true}
+	for _, id := range in.SourceDictIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		sources = append(sources, id)
+	}
+	if len(sources) == 0 {
+		return nil, ErrNoSourceDictionaries
+	}
+	for _, id := range sources {
+		if _, err := uc.GetDictionaryForUser(ctx, id, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var target *entity.Dictionary
+	var err error
+	if in.TargetDictID > 0 {
+		target, err = uc.GetDictionaryForUser(ctx, in.TargetDictID, userID)
+	} else {
+		name := strings.TrimSpace(in.Name)
+		if name == "" {
+			return nil, ErrEmptyDictionaryName
+		}
+		target, err = uc.CreateDictionary(ctx, name, in.Description, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{DeletedDictIDs: []int64{}}
+	for _, sourceID := range sources {
+		var words []*entity.Word
+		err := uc.wordRepo.StreamByDictID(ctx, sourceID, func(w *entity.Word) error {
+			words = append(words, w)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list source words: %w", err)
+		}
+
+		for _, w := range words {
+			merged, err := uc.mergeWordInto(ctx, w, target.ID)
+			if err != nil {
+				return nil, err
+			}
+			if merged {
+				result.Deduplicated++
+			} else {
+				result.Moved++
+			}
+		}
+
+		if err := uc.dictRepo.Delete(ctx, sourceID); err != nil {
+			return nil, fmt.Errorf("failed to delete merged dictionary: %w", err)
+		}
+		if err := uc.cancelUploadTasks(ctx, sourceID); err != nil {
+			return nil, err
+		}
+		result.DeletedDictIDs = append(result.DeletedDictIDs, sourceID)
+	}
+
+	if result.Dictionary, err = uc.dictRepo.GetByID(ctx, target.ID); err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("dictionaries merged user_id=%d target=%d sources=%v moved=%d deduplicated=%d",
+		userID, target.ID, sources, result.Moved, result.Deduplicated)
+	return result, nil
+}
+
+// mergeWordInto 将单词移入目标词典，目标词典已有同一词元时合并为一条，返回是否发生了合并
+func (uc *DictionaryUseCase) mergeWordInto(ctx context.Context, w *entity.Word, targetDictID int64) (bool, error) {
+	existing, err := uc.wordRepo.GetByDictIDAndLemma(ctx, targetDictID, w.Lemma)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existing word: %w", err)
+	}
+	if existing == nil {
+		err := uc.wordRepo.MoveToDict(ctx, w.ID, targetDictID)
+		if errors.Is(err, repo.ErrDuplicateWord) {
+			// 检查之后目标词典并发写入了同一词元
+			return false, ErrWordExists
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to move word: %w", err)
+		}
+		return false, nil
+	}
+
+	keep, drop := existing, w
+	if moreAdvanced(w, existing) {
+		keep, drop = w, existing
+	}
+	// 保留被删除单词上的笔记与助记；转移记录、删除与移动在同一事务中完成
+	notes, mnemonic := firstNonEmpty(keep.Notes, drop.Notes), firstNonEmpty(keep.Mnemonic, drop.Mnemonic)
+	err = uc.wordRepo.MergeDuplicate(ctx, keep.ID, drop.ID, targetDictID, notes, mnemonic)
+	if errors.Is(err, repo.ErrDuplicateWord) {
+		return false, ErrWordExists
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to merge duplicate word: %w", err)
+	}
+	return true, nil
+}
+
+// SplitDictionary 拆分词典：按筛选条件把匹配的单词移入一个新词典，或每 N 个单词拆分为一个新词典
+func (uc *DictionaryUseCase) SplitDictionary(ctx context.Context, userID int64, in *SplitInput) (*SplitResult, error) {
+	source, err := uc.GetDictionaryForUser(ctx, in.DictID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if (in.ChunkSize > 0) == (in.Filter != nil) {
+		return nil, ErrInvalidSplitMode
+	}
+	for _, status := range statusesOf(in.Filter) {
+		if !isWordStatus(status) {
+			return nil, ErrInvalidWordStatus
+		}
+	}
+
+	filter := in.Filter
+	if filter == nil {
+		filter = &entity.WordFilter{}
+	}
+	filter.DictID = source.ID
+	ids, err := uc.collectWordIDs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// 分组：按筛选拆分时全部匹配单词为一组；按数量拆分时源词典保留第一组
+	var groups [][]int64
+	if in.ChunkSize > 0 {
+		for start := in.ChunkSize; start < len(ids); start += in.ChunkSize {
+			groups = append(groups, ids[start:min(start+in.ChunkSize, len(ids))])
+		}
+		if len(groups) > maxSplitParts {
+			return nil, ErrTooManySplitParts
+		}
+	} else if len(ids) > 0 {
+		groups = [][]int64{ids}
+	}
+	if len(groups) == 0 {
+		return nil, ErrNothingToSplit
+	}
+
+	// 未指定名称时沿用源词典名称并编号，源词典视为第 1 部分
+	name := strings.TrimSpace(in.Name)
+	numbered := name == "" || len(groups) > 1
+	if name == "" {
+		name = source.Name
+	}
+	result := &SplitResult{}
+	for i, group := range groups {
+		partName := name
+		if numbered {
+			partName = fmt.Sprintf("%s (%d)", name, i+2)
+		}
+		dict, err := uc.CreateDictionary(ctx, partName, source.Description, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.wordRepo.MoveManyToDict(ctx, group, dict.ID); err != nil {
+			return nil, fmt.Errorf("failed to move words: %w", err)
+		}
+		if dict, err = uc.dictRepo.GetByID(ctx, dict.ID); err != nil {
+			return nil, err
+		}
+		result.Dictionaries = append(result.Dictionaries, dict)
+		result.Moved += len(group)
+	}
+	return result, nil
+}
+
+// collectWordIDs 按添加顺序分批读取符合条件的单词 ID
+func (uc *DictionaryUseCase) collectWordIDs(ctx context.Context, filter *entity.WordFilter) ([]int64, error) {
+	filter.Sort = entity.WordSortCreated
+	filter.Descending = false
+	filter.AfterID = 0
+	filter.Limit = splitPageSize
+
+	var ids []int64
+	for {
+		words, err := uc.wordRepo.ListWords(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list words: %w", err)
+		}
+		for _, w := range words {
+			ids = append(ids, w.ID)
+		}
+		if len(words) < splitPageSize {
+			return ids, nil
+		}
+		filter.AfterID = words[len(words)-1].ID
+	}
+}
+
+func statusesOf(f *entity.WordFilter) []string {
+	if f == nil {
+		return nil
+	}
+	return f.Statuses
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
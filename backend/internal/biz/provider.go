@@ -0,0 +1,91 @@
+// internal/biz/provider.go
+package biz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"backend/pkg/translator"
+)
+
+const (
+	// minProviderRetryDelay / maxProviderRetryDelay 等待提供方恢复时的检查间隔范围
+	minProviderRetryDelay = time.Second
+	maxProviderRetryDelay = 30 * time.Second
+)
+
+// ProviderHealth 返回各翻译提供方的健康状态；翻译器不支持健康报告时返回空
+func (uc *DictionaryUseCase) ProviderHealth() []translator.ProviderHealth {
+	reporter, ok := uc.translator.(translator.HealthReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.Health()
+}
+
+// providerRetryDelay 距最早一个熔断提供方允许探测的时间
+func (uc *DictionaryUseCase) providerRetryDelay() time.Duration {
+	delay := maxProviderRetryDelay
+	for _, h := range uc.ProviderHealth() {
+		if h.State == translator.BreakerOpen {
+			if d := time.Until(h.RetryAt); d < delay {
+				delay = d
+			}
+		}
+	}
+	if delay < minProviderRetryDelay {
+		delay = minProviderRetryDelay
+	}
+	return delay
+}
+
+// providerWait 上传任务是否处于等待提供方恢复的状态，由同一任务的所有 worker 共享
+type providerWait struct {
+	mu      sync.Mutex
+	waiting bool
+}
+
+// translateUpload 翻译上传的单词。提供方熔断时不记为失败，而是把任务标记为 waiting_provider，
+// 等到提供方允许探测后重试；任一单词重新得到结果后恢复为 processing。
+func (uc *DictionaryUseCase) translateUpload(ctx context.Context, taskID, word string, wait *providerWait) (*translator.WordDetail, error) {
+	for {
+		detail, err := uc.translate(ctx, word)
+		if !errors.Is(err, translator.ErrUnavailable) {
+			uc.setProviderWaiting(ctx, taskID, wait, false)
+			return detail, err
+		}
+		uc.setProviderWaiting(ctx, taskID, wait, true)
+
+		timer := time.NewTimer(uc.providerRetryDelay())
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		if uc.isTaskCancelled(ctx, taskID) {
+			return nil, context.Canceled
+		}
+	}
+}
+
+// setProviderWaiting 切换任务的等待状态，只在状态变化时写库
+func (uc *DictionaryUseCase) setProviderWaiting(ctx context.Context, taskID string, wait *providerWait, waiting bool) {
+	wait.mu.Lock()
+	defer wait.mu.Unlock()
+	if wait.waiting == waiting {
+		return
+	}
+	from, to := "processing", "waiting_provider"
+	if !waiting {
+		from, to = to, from
+	}
+	if _, err := uc.taskRepo.TransitionStatus(ctx, taskID, from, to); err != nil {
+		uc.log.Warnf("failed to update upload task status task_id=%s status=%s err=%v", taskID, to, err)
+		return
+	}
+	wait.waiting = waiting
+	uc.log.Infof("upload task %s task_id=%s", to, taskID)
+}
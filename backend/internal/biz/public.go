@@ -0,0 +1,82 @@
+// internal/biz/public.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/biz/entity"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrPublicDictionaryNotFound = kerrors.NotFound("PUBLIC_DICTIONARY_NOT_FOUND", "公开词典不存在")
+)
+
+const (
+	defaultCatalogLimit = 20
+	maxCatalogLimit     = 100
+)
+
+// PublicCatalog 公开词典目录
+type PublicCatalog struct {
+	Items   []*entity.PublicDictionary `json:"items"`
+	HasMore bool                       `json:"has_more"`
+}
+
+// ListPublicDictionaries 检索公开词典目录，keyword 匹配名称或描述
+func (uc *DictionaryUseCase) ListPublicDictionaries(ctx context.Context, keyword string, limit, offset int) (*PublicCatalog, error) {
+	if limit <= 0 {
+		limit = defaultCatalogLimit
+	}
+	if limit > maxCatalogLimit {
+		limit = maxCatalogLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	items, err := uc.dictRepo.ListPublic(ctx, strings.TrimSpace(keyword), limit+1, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public dictionaries: %w", err)
+	}
+
+	catalog := &PublicCatalog{Items: items}
+	if len(items) > limit {
+		catalog.Items = items[:limit]
+		catalog.HasMore = true
+	}
+	return catalog, nil
+}
+
+// CloneDictionary 将公开词典克隆到当前用户账号
+// 只复制单词、音标、释义与例句，记忆状态从新词开始，不调用翻译 API
+func (uc *DictionaryUseCase) CloneDictionary(ctx context.Context, userID, dictID int64, name string) (*entity.Dictionary, error) {
+	source, err := uc.dictRepo.GetPublicByID(ctx, dictID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public dictionary: %w", err)
+	}
+	if source == nil {
+		return nil, ErrPublicDictionaryNotFound
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = source.Name
+	}
+	dict, err := uc.CreateDictionary(ctx, name, source.Description, userID)
+	if err != nil {
+		return nil, err
+	}
+	copied, err := uc.wordRepo.CopyToDict(ctx, source.ID, dict.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy words: %w", err)
+	}
+	if err := uc.dictRepo.IncrementCloneCount(ctx, source.ID); err != nil {
+		uc.log.WithContext(ctx).Warnf("failed to increment clone count dict_id=%d: %v", source.ID, err)
+	}
+
+	uc.log.WithContext(ctx).Infof("dictionary cloned source=%d target=%d user_id=%d words=%d", source.ID, dict.ID, userID, copied)
+	return uc.dictRepo.GetByID(ctx, dict.ID)
+}
@@ -3,6 +3,8 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"backend/internal/biz/entity"
 )
@@ -19,13 +21,30 @@ type DictionaryRepo interface {
 	Update(ctx context.Context, dict *entity.Dictionary) error
 	// Delete 删除词典（软删除）
 	Delete(ctx context.Context, id int64) error
-	// UpdateStats 更新词典统计信息
-	UpdateStats(ctx context.Context, id int64, totalWords, learnedWords int) error
+	// RecomputeStats 根据单词表重新计算词典统计，dictID 为 0 时处理全部词典，返回计数有偏差而被修正的词典数
+	RecomputeStats(ctx context.Context, dictID int64) (int, error)
 	// IsOwnedByUser 判断词典是否属于该用户
 	IsOwnedByUser(ctx context.Context, dictID, userID int64) (bool, error)
+	// ListDeletedByUserID 获取用户回收站中的词典
+	ListDeletedByUserID(ctx context.Context, userID int64) ([]*entity.Dictionary, error)
+	// GetDeletedForUser 获取用户回收站中的词典，不存在时返回 nil
+	GetDeletedForUser(ctx context.Context, dictID, userID int64) (*entity.Dictionary, error)
+	// Restore 从回收站恢复词典
+	Restore(ctx context.Context, id int64) error
+	// Purge 彻底删除已软删除的词典，单词、学习记录与上传任务级联删除
+	Purge(ctx context.Context, id int64) error
+	// ListPublic 分页查询公开词典，keyword 匹配名称或描述
+	ListPublic(ctx context.Context, keyword string, limit, offset int) ([]*entity.PublicDictionary, error)
+	// GetPublicByID 获取公开词典，不存在或未公开时返回 nil
+	GetPublicByID(ctx context.Context, id int64) (*entity.Dictionary, error)
+	// IncrementCloneCount 克隆次数加一
+	IncrementCloneCount(ctx context.Context, id int64) error
 }
 
-// WordRepo 单词仓库接口
+// ErrDuplicateWord 目标词典中已有同一词元的单词，写入单词或移动单词时由唯一约束检出
+var ErrDuplicateWord = errors.New("word with the same lemma already exists in dictionary")
+
+// WordRepo 单词仓库接口，创建与移动单词违反词元唯一约束时返回 ErrDuplicateWord
 type WordRepo interface {
 	// Create 创建单词
 	Create(ctx context.Context, word *entity.Word) error
@@ -33,32 +52,77 @@ type WordRepo interface {
 	CreateBatch(ctx context.Context, words []*entity.Word) error
 	// GetByID 根据 ID 获取单词
 	GetByID(ctx context.Context, id int64) (*entity.Word, error)
-	// GetByIDForUser 根据用户归属获取单词
+	// GetByIDForUser 根据用户归属获取单词，不存在或不属于该用户时返回 nil
 	GetByIDForUser(ctx context.Context, id, userID int64) (*entity.Word, error)
 	// GetByDictIDAndWord 根据词典 ID 和单词获取
 	GetByDictIDAndWord(ctx context.Context, dictID int64, word string) (*entity.Word, error)
+	// GetByDictIDAndLemma 根据词典 ID 和词元获取（导入去重），不存在时返回 nil
+	GetByDictIDAndLemma(ctx context.Context, dictID int64, lemma string) (*entity.Word, error)
 	// GetByUserAndWord 根据用户和单词获取（跨词典复用）
 	GetByUserAndWord(ctx context.Context, userID int64, word string) (*entity.Word, error)
-	// ListByDictID 获取词典的单词列表
-	ListByDictID(ctx context.Context, dictID int64, offset, limit int) ([]*entity.Word, error)
-	// CountByDictID 统计词典单词数
-	CountByDictID(ctx context.Context, dictID int64) (int, error)
-	// Update 更新单词
+	// GetByUserAndLemma 根据用户和词元获取（跨词典复用），不存在时返回 nil
+	GetByUserAndLemma(ctx context.Context, userID int64, lemma string) (*entity.Word, error)
+	// ListKnownWords 返回给定词元中用户已经学会（复习中或已掌握）的部分
+	ListKnownWords(ctx context.Context, userID int64, lemmas []string) ([]string, error)
+	// ListLemmasInDict 返回给定词元中词典已包含的部分
+	ListLemmasInDict(ctx context.Context, dictID int64, lemmas []string) ([]string, error)
+	// ListLemmasForUser 返回给定词元中用户任一词典已包含的部分
+	ListLemmasForUser(ctx context.Context, userID int64, lemmas []string) ([]string, error)
+	// ListWords 按条件游标分页查询单词
+	ListWords(ctx context.Context, filter *entity.WordFilter) ([]*entity.Word, error)
+	// SearchWords 在用户全部词典中全文检索单词
+	SearchWords(ctx context.Context, userID int64, query *entity.WordSearchQuery) ([]*entity.WordSearchHit, error)
+	// CountByStatus 按学习状态统计词典单词数，tag 非空时只统计带该标签的单词
+	CountByStatus(ctx context.Context, dictID int64, tag string) (map[string]int, error)
+	// StreamByDictID 逐条遍历词典单词（用于导出等大批量场景）
+	StreamByDictID(ctx context.Context, dictID int64, fn func(*entity.Word) error) error
+	// 以下写操作在同一事务中同步维护词典的各状态计数
+	// Update 更新单词的释义与记忆状态，不修改用户的笔记与助记
 	Update(ctx context.Context, word *entity.Word) error
-	// GetTodayTasks 获取今日学习任务
-	GetTodayTasks(ctx context.Context, dictID int64, limit int) ([]*entity.Word, error)
+	// UpdateNotes 更新单词的笔记与助记
+	UpdateNotes(ctx context.Context, id int64, notes, mnemonic string) error
+	// Delete 删除单词
+	Delete(ctx context.Context, id int64) error
+	// MoveToDict 将单词移动到另一词典
+	MoveToDict(ctx context.Context, id, dictID int64) error
+	// MoveManyToDict 将多个单词移动到另一词典
+	MoveManyToDict(ctx context.Context, ids []int64, dictID int64) error
+	// MergeDuplicate 合并重复单词：dropID 的学习记录转移到 keepID 并删除 dropID，
+	// keepID 写入合并后的笔记与助记并移入 dictID
+	MergeDuplicate(ctx context.Context, keepID, dropID, dictID int64, notes, mnemonic string) error
+	// CopyToDict 复制词典全部单词到另一词典（记忆状态重置），返回复制数量
+	CopyToDict(ctx context.Context, fromDictID, toDictID int64) (int, error)
+	// GetTodayTasks 获取今日学习任务，tag 非空时只返回带该标签的单词
+	GetTodayTasks(ctx context.Context, dictID int64, tag string, limit int) ([]*entity.Word, error)
 	// CountReviewToday 统计今日待复习数
-	CountReviewToday(ctx context.Context, dictID int64) (int, error)
+	CountReviewToday(ctx context.Context, dictID int64, tag string) (int, error)
 	// CountNewWords 统计新词数
-	CountNewWords(ctx context.Context, dictID int64) (int, error)
+	CountNewWords(ctx context.Context, dictID int64, tag string) (int, error)
+	// RecomputeLemmas 按 lemmaOf 重新计算全部单词的词元，只更新不一致的行，返回更新数量
+	RecomputeLemmas(ctx context.Context, lemmaOf func(word string) string) (int, error)
+	// ListEnrichmentDictIDs 返回存在待补全单词的词典（不含已删除词典），
+	// retryBefore 之后尝试过补全的单词不计入
+	ListEnrichmentDictIDs(ctx context.Context, sources []entity.LexiconSource, retryBefore time.Time) ([]int64, error)
+	// ListEnrichmentCandidates 按 ID 游标返回词典中待补全的单词：缺少音标/例句/释义，
+	// 或释义来自的提供方版本不在 sources 中
+	ListEnrichmentCandidates(ctx context.Context, dictID int64, sources []entity.LexiconSource, retryBefore time.Time, afterID int64, limit int) ([]*entity.Word, error)
+	// CountEnrichment 统计词典中待补全的单词
+	CountEnrichment(ctx context.Context, dictID int64, sources []entity.LexiconSource) (*entity.EnrichmentStats, error)
+	// UpdateEnrichment 只更新翻译器给出的字段（音标、释义、例句、词频、提供方）并记录补全时间；
+	// 单词在 readAt 之后被修改过时不做更新并返回 false
+	UpdateEnrichment(ctx context.Context, word *entity.Word, readAt time.Time) (bool, error)
+	// MarkEnrichmentAttempted 只记录补全时间，用于查询无结果的单词
+	MarkEnrichmentAttempted(ctx context.Context, id int64) error
 }
 
 // LearnRecordRepo 学习记录仓库接口
 type LearnRecordRepo interface {
 	// Create 创建学习记录
 	Create(ctx context.Context, record *entity.LearnRecord) error
-	// ListByWordID 获取单词的学习记录
+	// ListByWordID 获取单词的学习记录，limit <= 0 表示不限制条数
 	ListByWordID(ctx context.Context, wordID int64, limit int) ([]*entity.LearnRecord, error)
+	// ListByWordIDs 批量获取多个单词的全部学习记录，按单词 ID 分组，组内按时间倒序
+	ListByWordIDs(ctx context.Context, wordIDs []int64) (map[int64][]*entity.LearnRecord, error)
 }
 
 // UploadTaskRepo 上传任务仓库接口
@@ -75,4 +139,10 @@ type UploadTaskRepo interface {
 	AddFailedWord(ctx context.Context, id string, word string) error
 	// AddFailedWordWithReason 添加失败单词和失败原因
 	AddFailedWordWithReason(ctx context.Context, id, word, stage, reason string) error
+	// TransitionStatus 仅当任务处于 from 状态时改为 to，返回是否更新
+	TransitionStatus(ctx context.Context, id, from, to string) (bool, error)
+	// UpdateIfStatus 仅当任务处于 from 状态时按 task 更新状态、计数与失败列表，返回是否更新
+	UpdateIfStatus(ctx context.Context, task *entity.UploadTask, from string) (bool, error)
+	// CancelUnfinishedByDictID 取消词典下未完成（pending/processing/waiting_provider）的任务
+	CancelUnfinishedByDictID(ctx context.Context, dictID int64) error
 }
@@ -0,0 +1,20 @@
+// internal/biz/repo/lexicon.go
+package repo
+
+import (
+	"context"
+
+	"backend/internal/biz/entity"
+)
+
+// LexiconRepo 全局词库缓存仓库接口
+type LexiconRepo interface {
+	// Get 按 sources 的顺序返回第一个未过期且版本一致的缓存，不存在时返回 nil
+	Get(ctx context.Context, word string, sources []entity.LexiconSource) (*entity.LexiconEntry, error)
+	// ListCached 返回给定单词中存在有效缓存的部分
+	ListCached(ctx context.Context, words []string, sources []entity.LexiconSource) ([]string, error)
+	// Put 写入或覆盖缓存（按单词与提供方唯一）
+	Put(ctx context.Context, entry *entity.LexiconEntry) error
+	// Invalidate 删除缓存，word/provider 为空表示不限，返回删除条数
+	Invalidate(ctx context.Context, word, provider string) (int, error)
+}
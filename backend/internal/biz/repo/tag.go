@@ -0,0 +1,26 @@
+// internal/biz/repo/tag.go
+package repo
+
+import (
+	"context"
+
+	"backend/internal/biz/entity"
+)
+
+// TagRepo 标签仓库接口
+type TagRepo interface {
+	// Ensure 获取用户的标签，不存在的自动创建
+	Ensure(ctx context.Context, userID int64, names []string) ([]*entity.Tag, error)
+	// ListByUserID 获取用户的全部标签及其单词数
+	ListByUserID(ctx context.Context, userID int64) ([]*entity.Tag, error)
+	// ListByNames 按名称获取用户已有的标签
+	ListByNames(ctx context.Context, userID int64, names []string) ([]*entity.Tag, error)
+	// Delete 删除标签（单词关联级联删除）
+	Delete(ctx context.Context, userID, tagID int64) (bool, error)
+	// AddWords 为单词打上标签，已存在的关联忽略，返回新增关联数
+	AddWords(ctx context.Context, tagIDs, wordIDs []int64) (int, error)
+	// RemoveWords 移除单词上的标签，返回删除的关联数
+	RemoveWords(ctx context.Context, tagIDs, wordIDs []int64) (int, error)
+	// ListNamesByWordIDs 批量获取单词的标签名
+	ListNamesByWordIDs(ctx context.Context, wordIDs []int64) (map[int64][]string, error)
+}
@@ -0,0 +1,196 @@
+// internal/biz/search.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"backend/internal/biz/entity"
+	"backend/pkg/nlp"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrEmptySearchQuery = kerrors.BadRequest("EMPTY_SEARCH_QUERY", "检索词不能为空")
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// maxSearchQueryLength 检索词最大字符数
+	maxSearchQueryLength = 100
+	// highlightSnippetRunes 高亮片段的最大字符数
+	highlightSnippetRunes = 80
+)
+
+// SearchHighlight 命中字段的高亮片段，匹配部分以 <mark></mark> 包裹，其余内容已做 HTML 转义
+type SearchHighlight struct {
+	Field   string `json:"field"` // word/phonetic/meaning/example
+	Snippet string `json:"snippet"`
+}
+
+// SearchHit 检索结果
+type SearchHit struct {
+	*entity.WordSearchHit
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// SearchResult 检索结果列表
+type SearchResult struct {
+	Hits    []*SearchHit `json:"hits"`
+	HasMore bool         `json:"has_more"`
+}
+
+// SearchWords 在用户全部词典中检索单词、音标、释义与例句
+// 例如只记得释义“放弃”或 “give up” 时也能找回单词
+func (uc *DictionaryUseCase) SearchWords(ctx context.Context, userID int64, query string, limit, offset int) (*SearchResult, error) {
+	term := nlp.Fold(query)
+	if r := []rune(term); len(r) > maxSearchQueryLength {
+		term = string(r[:maxSearchQueryLength])
+	}
+	terms := searchTerms(term)
+	if len(terms) == 0 {
+		return nil, ErrEmptySearchQuery
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	prefixes := make([]string, 0, len(terms))
+	for _, t := range terms {
+		prefixes = append(prefixes, t+":*")
+	}
+	hits, err := uc.wordRepo.SearchWords(ctx, userID, &entity.WordSearchQuery{
+		TSQuery: strings.Join(prefixes, " & "),
+		Term:    term,
+		Limit:   limit + 1,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+
+	result := &SearchResult{Hits: make([]*SearchHit, 0, len(hits))}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		result.HasMore = true
+	}
+	for _, hit := range hits {
+		result.Hits = append(result.Hits, &SearchHit{
+			WordSearchHit: hit,
+			Highlights:    highlightWord(hit.Word, terms),
+		})
+	}
+	return result, nil
+}
+
+// searchTerms 将检索词按非字母数字字符切分；中文连续字符作为一个整体
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlightWord 为单词各字段生成高亮片段，未命中的字段不返回
+func highlightWord(w *entity.Word, terms []string) []SearchHighlight {
+	fields := []struct{ name, text string }{
+		{"word", w.Word},
+		{"phonetic", w.Phonetic},
+		{"meaning", w.Meaning.Text()},
+		{"example", w.Example},
+	}
+	var highlights []SearchHighlight
+	for _, f := range fields {
+		if snippet, ok := highlight(f.text, terms, highlightSnippetRunes); ok {
+			highlights = append(highlights, SearchHighlight{Field: f.name, Snippet: snippet})
+		}
+	}
+	return highlights
+}
+
+// highlight 在 text 中查找检索词（忽略大小写），截取首个命中附近的片段并标记所有命中
+func highlight(text string, terms []string, maxRunes int) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// 收集命中区间 [start, end)
+	var spans [][2]int
+	for _, t := range terms {
+		tr := []rune(t)
+		if len(tr) == 0 {
+			continue
+		}
+		for i := 0; i+len(tr) <= len(lower); i++ {
+			if string(lower[i:i+len(tr)]) == t {
+				spans = append(spans, [2]int{i, i + len(tr)})
+				i += len(tr) - 1
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	merged := spans[:1]
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s[0] <= last[1] {
+			if s[1] > last[1] {
+				last[1] = s[1]
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	// 以首个命中为中心截取片段
+	start, end := 0, len(runes)
+	if len(runes) > maxRunes {
+		first := merged[0]
+		start = first[0] - (maxRunes-(first[1]-first[0]))/2
+		if start < 0 {
+			start = 0
+		}
+		end = start + maxRunes
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxRunes
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, s := range merged {
+		if s[1] <= start || s[0] >= end {
+			continue
+		}
+		from, to := max(s[0], start), min(s[1], end)
+		b.WriteString(html.EscapeString(string(runes[pos:from])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString("</mark>")
+		pos = to
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
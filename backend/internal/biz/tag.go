@@ -0,0 +1,159 @@
+// internal/biz/tag.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"backend/internal/biz/entity"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrEmptyTag    = kerrors.BadRequest("EMPTY_TAG", "标签不能为空")
+	ErrTagTooLong  = kerrors.BadRequest("TAG_TOO_LONG", "标签长度不能超过 50 个字符")
+	ErrTagNotFound = kerrors.NotFound("TAG_NOT_FOUND", "标签不存在")
+)
+
+// maxTagLength 标签名最大字符数，与 tags.name 列长度一致
+const maxTagLength = 50
+
+// TagResult 批量打标签/移除标签结果
+type TagResult struct {
+	Affected int `json:"affected"` // 新增或删除的关联数
+}
+
+// NormalizeTag 规范化标签名：去除首尾空白并合并连续空白
+func NormalizeTag(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// normalizeTags 规范化并去重标签名，忽略空标签
+func normalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, ErrTagTooLong
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	if len(result) == 0 {
+		return nil, ErrEmptyTag
+	}
+	return result, nil
+}
+
+// splitTags 拆分导入文件中的标签单元格，支持 ; | ；分隔
+func splitTags(cell string) []string {
+	return strings.FieldsFunc(cell, func(r rune) bool {
+		return r == ';' || r == '|' || r == '；'
+	})
+}
+
+// ListTags 获取用户的全部标签及其单词数
+func (uc *DictionaryUseCase) ListTags(ctx context.Context, userID int64) ([]*entity.Tag, error) {
+	return uc.tagRepo.ListByUserID(ctx, userID)
+}
+
+// TagWords 为用户的单词批量打标签，不存在的标签自动创建
+func (uc *DictionaryUseCase) TagWords(ctx context.Context, userID int64, wordIDs []int64, names []string) (*TagResult, error) {
+	names, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkWordsOwned(ctx, userID, wordIDs); err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.tagRepo.Ensure(ctx, userID, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure tags: %w", err)
+	}
+	n, err := uc.tagRepo.AddWords(ctx, tagIDs(tags), wordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag words: %w", err)
+	}
+	return &TagResult{Affected: n}, nil
+}
+
+// UntagWords 批量移除用户单词上的标签
+func (uc *DictionaryUseCase) UntagWords(ctx context.Context, userID int64, wordIDs []int64, names []string) (*TagResult, error) {
+	names, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkWordsOwned(ctx, userID, wordIDs); err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.tagRepo.ListByNames(ctx, userID, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return &TagResult{}, nil
+	}
+	n, err := uc.tagRepo.RemoveWords(ctx, tagIDs(tags), wordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to untag words: %w", err)
+	}
+	return &TagResult{Affected: n}, nil
+}
+
+// DeleteTag 删除用户的标签，单词本身不受影响
+func (uc *DictionaryUseCase) DeleteTag(ctx context.Context, userID, tagID int64) error {
+	deleted, err := uc.tagRepo.Delete(ctx, userID, tagID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if !deleted {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// checkWordsOwned 校验全部单词均属于该用户，避免部分执行
+func (uc *DictionaryUseCase) checkWordsOwned(ctx context.Context, userID int64, wordIDs []int64) error {
+	if len(wordIDs) == 0 {
+		return ErrNoWordsSelected
+	}
+	for _, id := range wordIDs {
+		if _, err := uc.getWordForUser(ctx, id, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachTags 为单词列表填充标签名
+func (uc *DictionaryUseCase) attachTags(ctx context.Context, words []*entity.Word) error {
+	ids := make([]int64, 0, len(words))
+	for _, w := range words {
+		ids = append(ids, w.ID)
+	}
+	names, err := uc.tagRepo.ListNamesByWordIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list word tags: %w", err)
+	}
+	for _, w := range words {
+		w.Tags = names[w.ID]
+	}
+	return nil
+}
+
+func tagIDs(tags []*entity.Tag) []int64 {
+	ids := make([]int64, 0, len(tags))
+	for _, t := range tags {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
@@ -0,0 +1,54 @@
+// internal/biz/trash.go
+package biz
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/biz/entity"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrDictionaryNotInTrash = kerrors.NotFound("DICTIONARY_NOT_IN_TRASH", "回收站中不存在该词典")
+)
+
+// ListTrash 获取用户回收站中的词典
+func (uc *DictionaryUseCase) ListTrash(ctx context.Context, userID int64) ([]*entity.Dictionary, error) {
+	return uc.dictRepo.ListDeletedByUserID(ctx, userID)
+}
+
+// RestoreDictionary 从回收站恢复词典，已取消的上传任务不会自动恢复
+func (uc *DictionaryUseCase) RestoreDictionary(ctx context.Context, userID, dictID int64) (*entity.Dictionary, error) {
+	if _, err := uc.getTrashedDictionary(ctx, dictID, userID); err != nil {
+		return nil, err
+	}
+	if err := uc.dictRepo.Restore(ctx, dictID); err != nil {
+		return nil, fmt.Errorf("failed to restore dictionary: %w", err)
+	}
+	return uc.dictRepo.GetByID(ctx, dictID)
+}
+
+// PurgeDictionary 彻底删除回收站中的词典，单词与学习记录随之删除且不可恢复
+func (uc *DictionaryUseCase) PurgeDictionary(ctx context.Context, userID, dictID int64) error {
+	if _, err := uc.getTrashedDictionary(ctx, dictID, userID); err != nil {
+		return err
+	}
+	if err := uc.dictRepo.Purge(ctx, dictID); err != nil {
+		return fmt.Errorf("failed to purge dictionary: %w", err)
+	}
+	uc.log.WithContext(ctx).Infof("dictionary purged dict_id=%d user_id=%d", dictID, userID)
+	return nil
+}
+
+func (uc *DictionaryUseCase) getTrashedDictionary(ctx context.Context, dictID, userID int64) (*entity.Dictionary, error) {
+	dict, err := uc.dictRepo.GetDeletedForUser(ctx, dictID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted dictionary: %w", err)
+	}
+	if dict == nil {
+		return nil, ErrDictionaryNotInTrash
+	}
+	return dict, nil
+}
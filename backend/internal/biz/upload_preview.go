@@ -0,0 +1,198 @@
+// internal/biz/upload_preview.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"backend/internal/conf"
+	"backend/pkg/translator"
+)
+
+// 预览中单词的处理方式
+const (
+	PreviewActionExists    = "exists"    // 目标词典已存在，将跳过
+	PreviewActionReuse     = "reuse"     // 复用用户其他词典中的释义
+	PreviewActionProvided  = "provided"  // 使用文件自带的释义
+	PreviewActionCached    = "cached"    // 命中全局词库缓存，无需调用翻译 API
+	PreviewActionTranslate = "translate" // 需要调用翻译 API
+)
+
+// 翻译耗时预估使用的经验值
+const (
+	// requestLatency 单次网络请求的预估耗时，不超过配置的请求超时
+	requestLatency = 500 * time.Millisecond
+	// localLookupLatency 本地词典（ECDICT、StarDict）单次查询的预估耗时
+	localLookupLatency = time.Millisecond
+	// retryRatio 遇到 429/5xx 而需要重试的请求比例
+	retryRatio = 0.05
+	// retryBackoff 首次重试前的退避，与 translator 的默认值一致
+	retryBackoff = 500 * time.Millisecond
+	// defaultTranslateRetries 未配置 max_retries 时的重试次数，与 translator 的默认值一致
+	defaultTranslateRetries = 3
+)
+
+// TranslateEstimate 按链式翻译器首个启用提供方的限流与重试配置预估翻译耗时
+type TranslateEstimate struct {
+	Rate       float64       // 每秒请求数，0 表示不限流
+	Burst      int           // 允许的突发请求数
+	Latency    time.Duration // 单次请求的预估耗时
+	MaxRetries int           // 遇到 429/5xx 时的最大重试次数
+}
+
+// NewTranslateEstimate 根据翻译配置生成耗时预估，未启用任何提供方时按默认的 Free Dictionary 计算
+func NewTranslateEstimate(c *conf.Translator) *TranslateEstimate {
+	for _, p := range c.GetProviders() {
+		if !p.GetEnabled() {
+			continue
+		}
+		if p.GetName() == translator.ProviderECDICT || p.GetName() == translator.ProviderStarDict {
+			return &TranslateEstimate{Latency: localLookupLatency}
+		}
+		e := &TranslateEstimate{
+			Rate:       p.GetRateLimit(),
+			Burst:      max(int(p.GetBurst()), 1),
+			Latency:    requestLatency,
+			MaxRetries: defaultTranslateRetries,
+		}
+		if timeout := p.GetTimeout().AsDuration(); timeout > 0 && timeout < e.Latency {
+			e.Latency = timeout
+		}
+		if p.GetMaxRetries() != 0 {
+			e.MaxRetries = int(p.GetMaxRetries())
+		}
+		return e
+	}
+	return &TranslateEstimate{Latency: requestLatency, MaxRetries: defaultTranslateRetries}
+}
+
+// Duration 预估 words 个单词调用翻译 API 的耗时：取并发处理与限流放行两者中较慢的一个
+func (e *TranslateEstimate) Duration(words int) time.Duration {
+	if words <= 0 {
+		return 0
+	}
+	retries := 0.0
+	if e.MaxRetries > 0 {
+		retries = retryRatio
+	}
+	// 每批同时处理 uploadConcurrency 个单词，需要重试的请求先退避再请求一次
+	perWord := float64(e.Latency) + retries*float64(retryBackoff+e.Latency)
+	batches := (words + uploadConcurrency - 1) / uploadConcurrency
+	d := time.Duration(float64(batches) * perWord)
+	// 每次请求（含重试）消耗一个令牌，突发额度用完后按 Rate 放行
+	if e.Rate > 0 {
+		requests := float64(words)*(1+retries) - float64(e.Burst)
+		if limited := time.Duration(requests / e.Rate * float64(time.Second)); limited > d {
+			d = limited
+		}
+	}
+	return d
+}
+
+// UploadPreviewWord 预览中的单个单词
+type UploadPreviewWord struct {
+	Word   string `json:"word"`
+	Lemma  string `json:"lemma"`
+	Action string `json:"action"`
+}
+
+// UploadPreview 上传预览结果，不写入任何数据
+type UploadPreview struct {
+	Words             []*UploadPreviewWord `json:"words"`
+	Duplicates        []string             `json:"duplicates"` // 文件内重复而被合并的单词
+	InvalidLines      int                  `json:"invalid_lines"`
+	ExistingCount     int                  `json:"existing_count"`
+	ReusableCount     int                  `json:"reusable_count"`
+	ProvidedCount     int                  `json:"provided_count"`
+	CachedCount       int                  `json:"cached_count"`
+	TranslateCount    int                  `json:"translate_count"`
+	EstimatedDuration time.Duration        `json:"estimated_duration"`
+	ReceivedBytes     int64                `json:"received_bytes"`
+}
+
+// PreviewUpload 解析上传文件并预估导入结果：有效单词、文件内重复、目标词典已有、可跨词典复用、
+// 命中全局词库缓存以及需要调用翻译 API 的单词数与预计耗时。dictID 为 0 表示创建新词典。
+func (uc *DictionaryUseCase) PreviewUpload(ctx context.Context, reader io.Reader, dictID, userID int64) (*UploadPreview, error) {
+	if dictID > 0 {
+		if _, err := uc.GetDictionaryForUser(ctx, dictID, userID); err != nil {
+			return nil, err
+		}
+	}
+	file, err := uc.parseWordFile(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse word file: %w", err)
+	}
+	items, duplicates := dedupeUploadWords(file.Items)
+
+	lemmas := make([]string, 0, len(items))
+	for _, item := range items {
+		lemmas = append(lemmas, item.Lemma)
+	}
+	existing := map[string]bool{}
+	if dictID > 0 {
+		found, err := uc.wordRepo.ListLemmasInDict(ctx, dictID, lemmas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing words: %w", err)
+		}
+		existing = toSet(found)
+	}
+	found, err := uc.wordRepo.ListLemmasForUser(ctx, userID, lemmas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check reusable words: %w", err)
+	}
+	reusable := toSet(found)
+
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, lexiconKey(item.Word))
+	}
+	found, err = uc.lexiconRepo.ListCached(ctx, keys, uc.lexicon.Sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check lexicon cache: %w", err)
+	}
+	cached := toSet(found)
+
+	preview := &UploadPreview{
+		Words:         make([]*UploadPreviewWord, 0, len(items)),
+		Duplicates:    duplicates,
+		InvalidLines:  file.InvalidLines,
+		ReceivedBytes: file.Bytes,
+	}
+	if preview.Duplicates == nil {
+		preview.Duplicates = []string{}
+	}
+	// 判断顺序与 processUploadTask 保持一致
+	for _, item := range items {
+		w := &UploadPreviewWord{Word: item.Word, Lemma: item.Lemma}
+		switch {
+		case existing[item.Lemma]:
+			w.Action = PreviewActionExists
+			preview.ExistingCount++
+		case reusable[item.Lemma]:
+			w.Action = PreviewActionReuse
+			preview.ReusableCount++
+		case item.Meaning != "":
+			w.Action = PreviewActionProvided
+			preview.ProvidedCount++
+		case cached[lexiconKey(item.Word)]:
+			w.Action = PreviewActionCached
+			preview.CachedCount++
+		default:
+			w.Action = PreviewActionTranslate
+			preview.TranslateCount++
+		}
+		preview.Words = append(preview.Words, w)
+	}
+	preview.EstimatedDuration = uc.estimate.Duration(preview.TranslateCount)
+	return preview, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
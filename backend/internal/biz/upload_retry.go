@@ -0,0 +1,135 @@
+// internal/biz/upload_retry.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/biz/entity"
+	"backend/pkg/nlp"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrUploadTaskRunning = kerrors.Conflict("UPLOAD_TASK_RUNNING", "上传任务尚未结束，请结束后再重试")
+	ErrNoFailedWords     = kerrors.BadRequest("NO_FAILED_WORDS", "没有可重试的失败单词")
+	ErrInvalidRetryStage = kerrors.BadRequest("INVALID_RETRY_STAGE", "无效的失败阶段，仅支持 reuse、save、translate")
+)
+
+// uploadStages 上传单词可能失败的阶段
+var uploadStages = map[string]bool{"reuse": true, "save": true, "translate": true}
+
+// RetryUploadInput 重试上传任务失败单词的参数
+type RetryUploadInput struct {
+	TaskID string
+	Stages []string // 只重试这些阶段失败的单词，为空表示全部
+	// ManualMeanings 单词到手工释义的映射（按词元匹配），重试后仍查不到释义时使用
+	ManualMeanings map[string]string
+}
+
+// RetryUploadTask 重新处理已结束（completed/failed）的上传任务中失败的单词。
+// 词典已移入回收站时不能重试。被重试的单词从失败列表中移除并扣减已处理数，任务回到 processing，处理结束后按原规则更新状态。
+// 重试时没有原文件中的例句与标签。
+func (uc *DictionaryUseCase) RetryUploadTask(ctx context.Context, userID int64, in *RetryUploadInput) (*entity.UploadTask, error) {
+	stages := make(map[string]bool, len(in.Stages))
+	for _, s := range in.Stages {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !uploadStages[s] {
+			return nil, ErrInvalidRetryStage
+		}
+		stages[s] = true
+	}
+
+	task, err := uc.taskRepo.GetByID(ctx, in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil || task.DictID == nil {
+		return nil, ErrUnauthorized
+	}
+	// 与其他词典修改一致：校验归属，回收站中的词典不能再写入
+	if _, err := uc.GetDictionaryForUser(ctx, *task.DictID, userID); err != nil {
+		return nil, err
+	}
+	if task.Status != "completed" && task.Status != "failed" {
+		return nil, ErrUploadTaskRunning
+	}
+
+	retry := retryFailedWords(task, stages)
+	if len(retry) == 0 {
+		return nil, ErrNoFailedWords
+	}
+	fallbacks := make(map[string]string, len(in.ManualMeanings))
+	for word, text := range in.ManualMeanings {
+		if text = strings.TrimSpace(text); text != "" {
+			fallbacks[nlp.LemmaKey(nlp.NormalizeSurface(word))] = text
+		}
+	}
+	items := make([]uploadWord, 0, len(retry))
+	for _, w := range task.FailedWords {
+		if retry[w] {
+			items = append(items, uploadWord{Word: w})
+		}
+	}
+	items = normalizeUploadWords(items)
+	for i := range items {
+		items[i].Fallback = fallbacks[items[i].Lemma]
+	}
+
+	from := task.Status
+	failedWords := make([]string, 0, len(task.FailedWords))
+	for _, w := range task.FailedWords {
+		if !retry[w] {
+			failedWords = append(failedWords, w)
+		}
+	}
+	failedDetails := make([]entity.FailedDetail, 0, len(task.FailedDetails))
+	for _, d := range task.FailedDetails {
+		if !retry[d.Word] {
+			failedDetails = append(failedDetails, d)
+		}
+	}
+	task.Status = "processing"
+	// 按移出失败列表的条数扣减，去重后的 items 可能少于这些条目
+	task.ProcessedWords -= len(task.FailedWords) - len(failedWords)
+	if task.ProcessedWords < 0 {
+		task.ProcessedWords = 0
+	}
+	task.FailedWords = failedWords
+	task.FailedDetails = failedDetails
+	task.CompletedAt = nil
+	ok, err := uc.taskRepo.UpdateIfStatus(ctx, task, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen upload task: %w", err)
+	}
+	if !ok {
+		// 并发的重试已抢先重新打开了任务
+		return nil, ErrUploadTaskRunning
+	}
+
+	go uc.processUploadTask(task.ID, *task.DictID, userID, items)
+	return task, nil
+}
+
+// retryFailedWords 返回需要重试的失败单词；指定阶段时只选取该阶段失败的单词，
+// 没有失败详情的旧记录只在不限阶段时重试
+func retryFailedWords(task *entity.UploadTask, stages map[string]bool) map[string]bool {
+	retry := make(map[string]bool)
+	detailed := make(map[string]bool, len(task.FailedDetails))
+	for _, d := range task.FailedDetails {
+		detailed[d.Word] = true
+		if len(stages) == 0 || stages[d.Stage] {
+			retry[d.Word] = true
+		}
+	}
+	if len(stages) == 0 {
+		for _, w := range task.FailedWords {
+			if !detailed[w] {
+				retry[w] = true
+			}
+		}
+	}
+	return retry
+}
@@ -0,0 +1,98 @@
+// internal/biz/vocabulary.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/pkg/nlp"
+	"backend/pkg/translator"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrEmptyArticle      = kerrors.BadRequest("EMPTY_ARTICLE", "文章内容为空")
+	ErrNoVocabularyFound = kerrors.BadRequest("NO_VOCABULARY_FOUND", "文章中没有需要学习的生词")
+	ErrArticleTooLong    = kerrors.BadRequest("ARTICLE_TOO_LONG", "文章内容过长")
+)
+
+const (
+	// defaultExtractMaxWords 未指定时单篇文章最多提取的生词数
+	defaultExtractMaxWords = 200
+	// maxArticleLength 文章最大字节数
+	maxArticleLength = 1 << 20
+)
+
+// ExtractVocabulary 从英文文章中提取生词并创建词典
+// 已学会（复习中或已掌握）的单词会被剔除，其余按通用语料词频排序（越常用越靠前，文章内次数作为次要依据），
+// 并以原文句子作为例句
+func (uc *DictionaryUseCase) ExtractVocabulary(ctx context.Context, text, name, description string, maxWords int, userID int64) (*UploadTaskResult, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrEmptyArticle
+	}
+	if len(text) > maxArticleLength {
+		return nil, ErrArticleTooLong
+	}
+	if maxWords <= 0 {
+		maxWords = defaultExtractMaxWords
+	}
+
+	candidates := nlp.ExtractVocabulary(text, nlp.ExtractOptions{})
+	lemmas := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		lemmas = append(lemmas, c.Lemma)
+	}
+	known, err := uc.wordRepo.ListKnownWords(ctx, userID, lemmas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known words: %w", err)
+	}
+	knownSet := make(map[string]bool, len(known))
+	for _, w := range known {
+		knownSet[w] = true
+	}
+
+	unknown := make([]*nlp.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !knownSet[c.Lemma] {
+			unknown = append(unknown, c)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil, ErrNoVocabularyFound
+	}
+	ranked := uc.rankByFrequency(ctx, unknown)
+
+	items := make([]uploadWord, 0, min(len(unknown), maxWords))
+	for _, c := range unknown[:min(len(unknown), maxWords)] {
+		items = append(items, uploadWord{Word: c.Lemma, Example: c.Sentence})
+	}
+
+	uc.log.WithContext(ctx).Infof("extracted vocabulary user_id=%d candidates=%d known=%d ranked=%d selected=%d",
+		userID, len(candidates), len(known), ranked, len(items))
+	return uc.startUploadTask(ctx, name, description, userID, items)
+}
+
+// rankByFrequency 查询候选词的通用语料词频排名并重新排序，返回查到排名的单词数。
+// 翻译器不支持词频排名（未启用 ECDICT 等本地词典）或查询出错时保持文章内次数的顺序。
+func (uc *DictionaryUseCase) rankByFrequency(ctx context.Context, candidates []*nlp.Candidate) int {
+	ranker, ok := uc.translator.(translator.FrequencyRanker)
+	if !ok {
+		return 0
+	}
+	ranked := 0
+	for _, c := range candidates {
+		rank, err := ranker.FrequencyRank(c.Lemma)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("failed to look up frequency rank word=%q: %v", c.Lemma, err)
+			return 0
+		}
+		if rank > 0 {
+			c.Rank = rank
+			ranked++
+		}
+	}
+	nlp.RankByFrequency(candidates)
+	return ranked
+}
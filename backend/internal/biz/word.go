@@ -0,0 +1,302 @@
+// internal/biz/word.go
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"backend/internal/biz/entity"
+	"backend/internal/biz/repo"
+	"backend/pkg/algorithm"
+	"backend/pkg/meaning"
+	"backend/pkg/nlp"
+	"backend/pkg/translator"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrWordNotFound          = kerrors.NotFound("WORD_NOT_FOUND", "单词不存在")
+	ErrWordExists            = kerrors.Conflict("WORD_EXISTS", "词典中已存在该单词")
+	ErrEmptyWord             = kerrors.BadRequest("EMPTY_WORD", "单词不能为空")
+	ErrSameDictionary        = kerrors.BadRequest("SAME_DICTIONARY", "目标词典与原词典相同")
+	ErrNoWordsSelected       = kerrors.BadRequest("NO_WORDS_SELECTED", "未选择任何单词")
+	ErrTranslateFailed       = kerrors.BadRequest("TRANSLATE_FAILED", "未查到释义，请手动填写")
+	ErrTranslatorUnavailable = kerrors.ServiceUnavailable("TRANSLATOR_UNAVAILABLE", "翻译服务暂不可用，请稍后重试或手动填写释义")
+	ErrNotesTooLong          = kerrors.BadRequest("NOTES_TOO_LONG", "笔记或助记内容过长")
+)
+
+// defaultEFFactor SM-2 初始遗忘因子
+const defaultEFFactor = 2.5
+
+// maxNotesLength 笔记与助记的最大字符数
+const maxNotesLength = 10000
+
+// AddWordInput 添加单词参数
+type AddWordInput struct {
+	DictID   int64
+	Word     string
+	Meaning  string // 用户自定义释义，为空时调用翻译 API
+	Phonetic string
+	Example  string
+	Notes    string // 个人笔记（Markdown）
+	Mnemonic string
+}
+
+// UpdateWordInput 编辑单词参数，nil 表示不修改
+type UpdateWordInput struct {
+	ID       int64
+	Phonetic *string
+	Meaning  *string
+	Example  *string
+	Notes    *string
+	Mnemonic *string
+	// Suspended 暂停或恢复学习，暂停的单词不出现在今日任务中
+	Suspended *bool
+}
+
+// TransferResult 移动/复制单词结果
+type TransferResult struct {
+	Transferred  int      `json:"transferred"`
+	SkippedWords []string `json:"skipped_words"`
+}
+
+// manualMeaning 将用户输入的释义文本转换为与翻译结果一致的结构：按行拆分，行首的词性用于分组
+func manualMeaning(text string) meaning.Meaning {
+	return meaning.ParseGlosses(text)
+}
+
+// AddWord 向用户自己的词典添加单个单词
+func (uc *DictionaryUseCase) AddWord(ctx context.Context, userID int64, in *AddWordInput) (*entity.Word, error) {
+	surface := nlp.NormalizeSurface(in.Word)
+	lemma := nlp.LemmaKey(surface)
+	if lemma == "" {
+		return nil, ErrEmptyWord
+	}
+	if _, err := uc.GetDictionaryForUser(ctx, in.DictID, userID); err != nil {
+		return nil, err
+	}
+
+	notes, mnemonic := strings.TrimSpace(in.Notes), strings.TrimSpace(in.Mnemonic)
+	if err := checkNotesLength(notes, mnemonic); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.wordRepo.GetByDictIDAndLemma(ctx, in.DictID, lemma)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing word: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrWordExists
+	}
+
+	word := &entity.Word{
+		DictID:   in.DictID,
+		Word:     surface,
+		Lemma:    lemma,
+		Phonetic: strings.TrimSpace(in.Phonetic),
+		Example:  strings.TrimSpace(in.Example),
+		Notes:    notes,
+		Mnemonic: mnemonic,
+		Status:   "new",
+		EFFactor: defaultEFFactor,
+	}
+	if strings.TrimSpace(in.Meaning) != "" {
+		word.Meaning = manualMeaning(in.Meaning)
+	} else {
+		detail, err := uc.translate(ctx, surface)
+		if errors.Is(err, translator.ErrUnavailable) {
+			return nil, ErrTranslatorUnavailable.WithCause(err)
+		}
+		if err != nil {
+			return nil, ErrTranslateFailed.WithCause(err)
+		}
+		word.Meaning = detail.Meaning
+		word.Provider = detail.Provider
+		word.ProviderVersion = uc.lexicon.version(detail.Provider)
+		word.Frequency = detail.Frequency
+		if word.Phonetic == "" {
+			word.Phonetic = detail.Phonetic
+		}
+		word.Example = pickExample(word.Example, detail.Example)
+	}
+
+	if err := uc.wordRepo.Create(ctx, word); err != nil {
+		if errors.Is(err, repo.ErrDuplicateWord) {
+			// 查询之后并发写入了同一词元
+			return nil, ErrWordExists
+		}
+		return nil, fmt.Errorf("failed to create word: %w", err)
+	}
+	return word, nil
+}
+
+// UpdateWord 编辑单词的音标、释义、例句、笔记与助记
+func (uc *DictionaryUseCase) UpdateWord(ctx context.Context, userID int64, in *UpdateWordInput) (*entity.Word, error) {
+	word, err := uc.getWordForUser(ctx, in.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先完成全部校验再写入，避免部分字段已保存后才返回校验错误
+	if in.Notes != nil {
+		word.Notes = strings.TrimSpace(*in.Notes)
+	}
+	if in.Mnemonic != nil {
+		word.Mnemonic = strings.TrimSpace(*in.Mnemonic)
+	}
+	if in.Notes != nil || in.Mnemonic != nil {
+		if err := checkNotesLength(word.Notes, word.Mnemonic); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.Phonetic != nil {
+		word.Phonetic = strings.TrimSpace(*in.Phonetic)
+	}
+	if in.Meaning != nil {
+		word.Meaning = manualMeaning(*in.Meaning)
+		word.Provider = ""
+		word.ProviderVersion = 0
+	}
+	if in.Example != nil {
+		word.Example = strings.TrimSpace(*in.Example)
+	}
+	if in.Suspended != nil {
+		word.Status = suspendedStatus(word, *in.Suspended)
+	}
+	if in.Phonetic != nil || in.Meaning != nil || in.Example != nil || in.Suspended != nil {
+		if err := uc.wordRepo.Update(ctx, word); err != nil {
+			return nil, fmt.Errorf("failed to update word: %w", err)
+		}
+	}
+	if in.Notes != nil || in.Mnemonic != nil {
+		if err := uc.wordRepo.UpdateNotes(ctx, word.ID, word.Notes, word.Mnemonic); err != nil {
+			return nil, fmt.Errorf("failed to update word notes: %w", err)
+		}
+	}
+	return word, nil
+}
+
+// DeleteWord 删除单词
+func (uc *DictionaryUseCase) DeleteWord(ctx context.Context, userID, wordID int64) error {
+	word, err := uc.getWordForUser(ctx, wordID, userID)
+	if err != nil {
+		return err
+	}
+	if err := uc.wordRepo.Delete(ctx, word.ID); err != nil {
+		return fmt.Errorf("failed to delete word: %w", err)
+	}
+	return nil
+}
+
+// MoveWords 将单词移动到用户的另一词典，保留记忆状态
+func (uc *DictionaryUseCase) MoveWords(ctx context.Context, userID int64, wordIDs []int64, targetDictID int64) (*TransferResult, error) {
+	return uc.transferWords(ctx, userID, wordIDs, targetDictID, true)
+}
+
+// CopyWords 将单词复制到用户的另一词典，副本从新词开始学习
+func (uc *DictionaryUseCase) CopyWords(ctx context.Context, userID int64, wordIDs []int64, targetDictID int64) (*TransferResult, error) {
+	return uc.transferWords(ctx, userID, wordIDs, targetDictID, false)
+}
+
+func (uc *DictionaryUseCase) transferWords(ctx context.Context, userID int64, wordIDs []int64, targetDictID int64, move bool) (*TransferResult, error) {
+	if len(wordIDs) == 0 {
+		return nil, ErrNoWordsSelected
+	}
+	if _, err := uc.GetDictionaryForUser(ctx, targetDictID, userID); err != nil {
+		return nil, err
+	}
+
+	// 先校验全部单词归属，避免部分执行
+	words := make([]*entity.Word, 0, len(wordIDs))
+	for _, id := range wordIDs {
+		word, err := uc.getWordForUser(ctx, id, userID)
+		if err != nil {
+			return nil, err
+		}
+		if word.DictID == targetDictID {
+			return nil, ErrSameDictionary
+		}
+		words = append(words, word)
+	}
+
+	result := &TransferResult{SkippedWords: []string{}}
+	for _, word := range words {
+		existing, err := uc.wordRepo.GetByDictIDAndLemma(ctx, targetDictID, word.Lemma)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing word: %w", err)
+		}
+		if existing != nil {
+			result.SkippedWords = append(result.SkippedWords, word.Word)
+			continue
+		}
+
+		if move {
+			err = uc.wordRepo.MoveToDict(ctx, word.ID, targetDictID)
+		} else {
+			copied := &entity.Word{
+				DictID:          targetDictID,
+				Word:            word.Word,
+				Lemma:           word.Lemma,
+				Phonetic:        word.Phonetic,
+				Meaning:         word.Meaning,
+				Example:         word.Example,
+				AudioURL:        word.AudioURL,
+				Frequency:       word.Frequency,
+				Provider:        word.Provider,
+				ProviderVersion: word.ProviderVersion,
+				Notes:           word.Notes,
+				Mnemonic:        word.Mnemonic,
+				Status:          "new",
+				EFFactor:        defaultEFFactor,
+			}
+			err = uc.wordRepo.Create(ctx, copied)
+		}
+		if errors.Is(err, repo.ErrDuplicateWord) {
+			// 检查之后目标词典并发写入了同一词元，与已存在时一样跳过
+			result.SkippedWords = append(result.SkippedWords, word.Word)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to transfer word: %w", err)
+		}
+		result.Transferred++
+	}
+
+	return result, nil
+}
+
+// checkNotesLength 校验笔记与助记长度
+func checkNotesLength(notes, mnemonic string) error {
+	if utf8.RuneCountInString(notes) > maxNotesLength || utf8.RuneCountInString(mnemonic) > maxNotesLength {
+		return ErrNotesTooLong
+	}
+	return nil
+}
+
+// getWordForUser 获取属于该用户的单词
+func (uc *DictionaryUseCase) getWordForUser(ctx context.Context, wordID, userID int64) (*entity.Word, error) {
+	word, err := uc.wordRepo.GetByIDForUser(ctx, wordID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word: %w", err)
+	}
+	if word == nil {
+		return nil, ErrWordNotFound
+	}
+	return word, nil
+}
+
+// suspendedStatus 计算暂停/恢复后的学习状态，恢复时按记忆参数还原
+func suspendedStatus(word *entity.Word, suspended bool) string {
+	if suspended {
+		return "suspended"
+	}
+	if word.Status != "suspended" {
+		return word.Status
+	}
+	return algorithm.GetWordStatus(word.Interval, word.Repetitions)
+}
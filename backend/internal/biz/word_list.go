@@ -0,0 +1,171 @@
+// internal/biz/word_list.go
+package biz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"backend/internal/biz/entity"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrInvalidCursor       = kerrors.BadRequest("INVALID_CURSOR", "分页游标无效")
+	ErrUnsupportedWordSort = kerrors.BadRequest("UNSUPPORTED_WORD_SORT", "不支持的排序方式")
+	ErrInvalidWordStatus   = kerrors.BadRequest("INVALID_WORD_STATUS", "无效的单词状态")
+	ErrInvalidDate         = kerrors.BadRequest("INVALID_DATE", "日期格式应为 YYYY-MM-DD")
+)
+
+const (
+	defaultWordPageSize = 50
+	maxWordPageSize     = 200
+)
+
+// wordStatuses 单词学习状态
+var wordStatuses = []string{"new", "learning", "review", "mastered", "suspended"}
+
+// WordListResult 单词列表查询结果
+type WordListResult struct {
+	Words        []*entity.Word
+	NextCursor   string         // 为空表示没有更多数据
+	StatusCounts map[string]int // 词典内各状态单词数（仅受标签筛选影响）
+	Total        int
+}
+
+// wordCursor 游标内容，记录生成游标时的排序方式以防与查询条件不一致
+type wordCursor struct {
+	Sort       entity.WordSort `json:"s"`
+	Descending bool            `json:"d,omitempty"`
+	Key        string          `json:"k,omitempty"`
+	ID         int64           `json:"id"`
+}
+
+// ParseWordSort 解析排序方式，空值默认为添加顺序
+func ParseWordSort(sort string) (entity.WordSort, error) {
+	switch s := entity.WordSort(strings.ToLower(strings.TrimSpace(sort))); s {
+	case "":
+		return entity.WordSortCreated, nil
+	case entity.WordSortCreated, entity.WordSortAlpha, entity.WordSortNextReview,
+		entity.WordSortEFFactor, entity.WordSortFrequency:
+		return s, nil
+	default:
+		return "", ErrUnsupportedWordSort
+	}
+}
+
+// ListWords 分页查询词典单词，支持按状态、遗忘因子、复习日期、标签等筛选
+func (uc *DictionaryUseCase) ListWords(ctx context.Context, userID int64, filter *entity.WordFilter, cursor string) (*WordListResult, error) {
+	if _, err := uc.GetDictionaryForUser(ctx, filter.DictID, userID); err != nil {
+		return nil, err
+	}
+	for _, status := range filter.Statuses {
+		if !isWordStatus(status) {
+			return nil, ErrInvalidWordStatus
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultWordPageSize
+	}
+	if filter.Limit > maxWordPageSize {
+		filter.Limit = maxWordPageSize
+	}
+	if cursor != "" {
+		c, err := decodeWordCursor(cursor)
+		if err != nil || c.Sort != filter.Sort || c.Descending != filter.Descending {
+			return nil, ErrInvalidCursor
+		}
+		filter.AfterKey, filter.AfterID = c.Key, c.ID
+	}
+
+	// 多取一条用于判断是否还有下一页
+	pageSize := filter.Limit
+	filter.Limit++
+	words, err := uc.wordRepo.ListWords(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words: %w", err)
+	}
+	counts, err := uc.wordRepo.CountByStatus(ctx, filter.DictID, filter.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count words by status: %w", err)
+	}
+
+	result := &WordListResult{Words: words, StatusCounts: make(map[string]int, len(wordStatuses))}
+	for _, status := range wordStatuses {
+		result.StatusCounts[status] = 0
+	}
+	for status, n := range counts {
+		result.StatusCounts[status] = n
+		result.Total += n
+	}
+	if len(words) > pageSize {
+		result.Words = words[:pageSize]
+		last := result.Words[pageSize-1]
+		result.NextCursor = encodeWordCursor(&wordCursor{
+			Sort:       filter.Sort,
+			Descending: filter.Descending,
+			Key:        wordSortKey(last, filter.Sort),
+			ID:         last.ID,
+		})
+	}
+	if err := uc.attachTags(ctx, result.Words); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// wordSortKey 计算单词在指定排序方式下的排序键，需与数据层的排序表达式保持一致
+func wordSortKey(w *entity.Word, sort entity.WordSort) string {
+	switch sort {
+	case entity.WordSortAlpha:
+		return w.Lemma
+	case entity.WordSortNextReview:
+		if w.NextReviewDate == nil {
+			return "9999-12-31"
+		}
+		return w.NextReviewDate.Format("2006-01-02")
+	case entity.WordSortEFFactor:
+		return strconv.FormatFloat(w.EFFactor, 'f', -1, 64)
+	case entity.WordSortFrequency:
+		if w.Frequency <= 0 {
+			return strconv.Itoa(math.MaxInt32)
+		}
+		return strconv.Itoa(w.Frequency)
+	default:
+		return ""
+	}
+}
+
+func encodeWordCursor(c *wordCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeWordCursor(cursor string) (*wordCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	c := &wordCursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func isWordStatus(status string) bool {
+	for _, s := range wordStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
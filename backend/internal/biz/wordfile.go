@@ -0,0 +1,190 @@
+// internal/biz/wordfile.go
+package biz
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"backend/pkg/wordfile"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrUploadTooLarge     = kerrors.New(413, "UPLOAD_TOO_LARGE", "上传文件超过大小限制")
+	ErrUploadTooManyLines = kerrors.BadRequest("UPLOAD_TOO_MANY_LINES", "上传文件行数超过限制")
+	ErrInvalidUploadForm  = kerrors.BadRequest("INVALID_UPLOAD_FORM", "上传表单格式错误")
+	ErrMissingUploadFile  = kerrors.BadRequest("MISSING_UPLOAD_FILE", "缺少上传文件 file")
+	ErrUploadLineTooLong  = kerrors.BadRequest("UPLOAD_LINE_TOO_LONG", "上传文件中有过长的行")
+	ErrUploadEncoding     = kerrors.BadRequest("UPLOAD_INVALID_ENCODING", "上传文件中有无法识别编码的内容")
+)
+
+const (
+	defaultUploadMaxBytes = 10 << 20 // 10 MiB
+	defaultUploadMaxLines = 50000
+	// maxUploadLineBytes 单行最大字节数，超过时返回 ErrUploadLineTooLong
+	maxUploadLineBytes = 1 << 20
+)
+
+// UploadLimits 单词文件上传限制
+type UploadLimits struct {
+	MaxBytes int64
+	MaxLines int
+}
+
+// wordFile 解析后的单词文件
+type wordFile struct {
+	Items        []uploadWord
+	Bytes        int64  // 实际读取的字节数
+	Charset      string // 检测到的字符集
+	InvalidLines int    // 无法识别为单词而被忽略的行数
+}
+
+// countingReader 统计读取字节数，超过上限时返回 ErrUploadTooLarge
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if c.max > 0 && c.n > c.max {
+		return n, ErrUploadTooLarge
+	}
+	return n, err
+}
+
+// parseWordFile 边读取边解析单词文件，不在内存中保留原始内容。
+// 自动识别 UTF-8/UTF-16/GBK 编码，跳过空行与 # 注释，支持编号列表、“单词 + 分隔符 + 释义”等行格式；
+// 若首行为包含 word 列的 CSV 表头，则按列读取，可选 tags（或 tag）、meaning 与 example 列。
+// 不是合法英文单词的行在调用翻译 API 之前即被忽略并计入 InvalidLines。
+func (uc *DictionaryUseCase) parseWordFile(reader io.Reader) (*wordFile, error) {
+	counter := &countingReader{r: reader, max: uc.limits.MaxBytes}
+	decoded, charset, err := wordfile.NewReader(counter)
+	if err != nil {
+		return nil, err
+	}
+	file := &wordFile{Charset: charset}
+	scanner := bufio.NewScanner(decoded)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxUploadLineBytes)
+
+	var (
+		parser *wordCSVParser
+		lines  int
+		lineNo int // 文件中的物理行号，用于错误提示
+	)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || wordfile.IsComment(line) {
+			continue
+		}
+		lines++
+		if uc.limits.MaxLines > 0 && lines > uc.limits.MaxLines {
+			return nil, ErrUploadTooManyLines
+		}
+		if lines == 1 {
+			if parser = newWordCSVParser(line); parser != nil {
+				continue
+			}
+		}
+
+		var (
+			item uploadWord
+			ok   bool
+		)
+		if parser != nil {
+			item, ok = parser.parse(line)
+		} else {
+			item, ok = parseWordLine(line)
+		}
+		if !ok {
+			file.InvalidLines++
+			continue
+		}
+		file.Items = append(file.Items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, kerrors.BadRequest(ErrUploadLineTooLong.Reason,
+				fmt.Sprintf("上传文件第 %d 行超过 %d KiB", lineNo+1, maxUploadLineBytes>>10))
+		}
+		var encErr *wordfile.EncodingError
+		if errors.As(err, &encErr) {
+			return nil, kerrors.BadRequest(ErrUploadEncoding.Reason,
+				fmt.Sprintf("上传文件第 %d 行不是有效的 UTF-8 编码", encErr.Line))
+		}
+		return nil, err
+	}
+	if len(file.Items) == 0 {
+		return nil, ErrEmptyWordFile
+	}
+	file.Bytes = counter.n
+	return file, nil
+}
+
+// parseWordLine 解析纯文本单词文件中的一行
+func parseWordLine(line string) (uploadWord, bool) {
+	entry, kind := wordfile.ParseLine(line)
+	if kind != wordfile.LineWord {
+		return uploadWord{}, false
+	}
+	return uploadWord{Word: entry.Word, Meaning: entry.Meaning}, true
+}
+
+// wordCSVParser 按表头逐行解析 CSV 单词文件（单元格内不支持换行）
+type wordCSVParser struct {
+	wordCol, tagCol, meaningCol, exampleCol int
+}
+
+// newWordCSVParser 首行不是含 word 列的 CSV 表头时返回 nil
+func newWordCSVParser(header string) *wordCSVParser {
+	cols, err := csv.NewReader(strings.NewReader(header)).Read()
+	if err != nil || len(cols) < 2 {
+		return nil
+	}
+	p := &wordCSVParser{wordCol: -1, tagCol: -1, meaningCol: -1, exampleCol: -1}
+	for i, name := range cols {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "word":
+			p.wordCol = i
+		case "tags", "tag":
+			p.tagCol = i
+		case "meaning", "definition":
+			p.meaningCol = i
+		case "example":
+			p.exampleCol = i
+		}
+	}
+	if p.wordCol < 0 {
+		return nil
+	}
+	return p
+}
+
+func (p *wordCSVParser) parse(line string) (uploadWord, bool) {
+	r := csv.NewReader(strings.NewReader(line))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	record, err := r.Read()
+	if err != nil || p.wordCol >= len(record) || !wordfile.ValidWord(record[p.wordCol]) {
+		return uploadWord{}, false
+	}
+	item := uploadWord{Word: record[p.wordCol]}
+	if p.tagCol >= 0 && p.tagCol < len(record) {
+		item.Tags = splitTags(record[p.tagCol])
+	}
+	if p.meaningCol >= 0 && p.meaningCol < len(record) {
+		item.Meaning = strings.TrimSpace(record[p.meaningCol])
+	}
+	if p.exampleCol >= 0 && p.exampleCol < len(record) {
+		item.Example = strings.TrimSpace(record[p.exampleCol])
+	}
+	return item, true
+}
@@ -0,0 +1,90 @@
+// internal/biz/wordlist.go
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/internal/biz/entity"
+	"backend/pkg/nlp"
+	"backend/pkg/wordlist"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	ErrWordlistNotFound = kerrors.NotFound("WORDLIST_NOT_FOUND", "内置词表不存在")
+)
+
+// BuiltinWordlist 内置词表信息
+type BuiltinWordlist struct {
+	wordlist.Info
+	WordCount int `json:"word_count"`
+}
+
+// ListBuiltinWordlists 获取全部内置词表
+func (uc *DictionaryUseCase) ListBuiltinWordlists() ([]*BuiltinWordlist, error) {
+	infos := wordlist.List()
+	lists := make([]*BuiltinWordlist, 0, len(infos))
+	for _, info := range infos {
+		entries, err := wordlist.Load(info.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wordlist %s: %w", info.ID, err)
+		}
+		lists = append(lists, &BuiltinWordlist{Info: info, WordCount: len(entries)})
+	}
+	return lists, nil
+}
+
+// CreateFromWordlist 基于内置词表创建词典
+// 词表释义已预先整理，单词直接批量写入，不调用翻译 API
+func (uc *DictionaryUseCase) CreateFromWordlist(ctx context.Context, userID int64, listID, name string) (*entity.Dictionary, error) {
+	info, ok := wordlist.Get(listID)
+	if !ok {
+		return nil, ErrWordlistNotFound
+	}
+	entries, err := wordlist.Load(listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wordlist %s: %w", listID, err)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = info.Name
+	}
+	dict, err := uc.CreateDictionary(ctx, name, info.Description, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(entries))
+	words := make([]*entity.Word, 0, len(entries))
+	for _, e := range entries {
+		surface := nlp.NormalizeSurface(e.Word)
+		lemma := nlp.LemmaKey(surface)
+		if lemma == "" || seen[lemma] {
+			continue
+		}
+		seen[lemma] = true
+		words = append(words, &entity.Word{
+			DictID:   dict.ID,
+			Word:     surface,
+			Lemma:    lemma,
+			Phonetic: e.Phonetic,
+			Meaning:  e.Meaning(),
+			Status:   "new",
+			EFFactor: defaultEFFactor,
+		})
+	}
+	if err := uc.wordRepo.CreateBatch(ctx, words); err != nil {
+		// 写入失败时移除空词典，避免留下残缺数据
+		if delErr := uc.dictRepo.Delete(ctx, dict.ID); delErr != nil {
+			uc.log.WithContext(ctx).Errorf("failed to delete dictionary after wordlist import dict_id=%d: %v", dict.ID, delErr)
+		}
+		return nil, fmt.Errorf("failed to import wordlist: %w", err)
+	}
+
+	uc.log.WithContext(ctx).Infof("dictionary created from wordlist list=%s dict_id=%d user_id=%d words=%d", listID, dict.ID, userID, len(words))
+	return uc.dictRepo.GetByID(ctx, dict.ID)
+}
@@ -23,7 +23,7 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, upload *conf.Upload, translator *conf.Translator, admin *conf.Admin, enrichment *conf.Enrichment, logger log.Logger) (*kratos.App, func(), error) {
 	dataData, cleanup, err := data.NewData(confData)
 	if err != nil {
 		return nil, nil, err
@@ -32,23 +32,37 @@ func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 	articleRepo := data.NewArticleRepo(dataData, logger)
 	greeterUsecase := biz.NewGreeterUsecase(greeterRepo, articleRepo)
 	greeterService := service.NewGreeterService(greeterUsecase, logger)
-	grpcServer := server.NewGRPCServer(confServer, greeterService, logger)
 	dictionaryRepo := data.NewDictionaryRepo(dataData, logger)
 	wordRepo := data.NewWordRepo(dataData, logger)
 	uploadTaskRepo := data.NewUploadTaskRepo(dataData, logger)
-	translator := biz.ProvideTranslator()
-	dictionaryUseCase := biz.NewDictionaryUseCase(dictionaryRepo, wordRepo, uploadTaskRepo, translator, logger)
-	dictionaryService := service.NewDictionaryService(dictionaryUseCase, logger)
 	learnRecordRepo := data.NewLearnRecordRepo(dataData, logger)
-	learningUseCase := biz.NewLearningUseCase(wordRepo, learnRecordRepo, dictionaryRepo)
-	learningService := service.NewLearningService(learningUseCase, logger)
+	tagRepo := data.NewTagRepo(dataData, logger)
+	lexiconRepo := data.NewLexiconRepo(dataData, logger)
+	translatorTranslator, cleanup2, err := biz.ProvideTranslator(translator, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	lexiconPolicy := biz.NewLexiconPolicy(translator)
+	uploadLimits := biz.NewUploadLimits(upload)
+	translateEstimate := biz.NewTranslateEstimate(translator)
+	enrichmentPolicy := biz.NewEnrichmentPolicy(enrichment)
+	dictionaryUseCase := biz.NewDictionaryUseCase(dictionaryRepo, wordRepo, uploadTaskRepo, learnRecordRepo, tagRepo, lexiconRepo, translatorTranslator, lexiconPolicy, uploadLimits, translateEstimate, enrichmentPolicy, logger)
+	dictionaryService := service.NewDictionaryService(dictionaryUseCase, logger)
 	userRepo := data.NewUserRepo(dataData, logger)
 	refreshTokenRepo := data.NewRefreshTokenRepo(dataData, logger)
 	authUseCase := biz.NewAuthUseCase(userRepo, refreshTokenRepo)
 	authService := service.NewAuthService(authUseCase)
-	httpServer := server.NewHTTPServer(confServer, greeterService, dictionaryService, learningService, authService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	adminUseCase := biz.NewAdminUseCase(lexiconRepo, admin, logger)
+	adminService := service.NewAdminService(adminUseCase)
+	grpcServer := server.NewGRPCServer(confServer, greeterService, dictionaryService, authService, adminService, logger)
+	learningUseCase := biz.NewLearningUseCase(wordRepo, learnRecordRepo, dictionaryRepo)
+	learningService := service.NewLearningService(learningUseCase, logger)
+	httpServer := server.NewHTTPServer(confServer, greeterService, dictionaryService, learningService, authService, adminService, logger)
+	enrichmentWorker := server.NewEnrichmentWorker(dictionaryUseCase, enrichmentPolicy, logger)
+	app := newApp(logger, grpcServer, httpServer, enrichmentWorker)
 	return app, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
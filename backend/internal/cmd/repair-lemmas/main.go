@@ -0,0 +1,68 @@
+// repair-lemmas 按应用使用的词形还原规则重新计算全部单词的词元。
+// 003_word_lemma.sql 只以小写形式回填存量数据，执行该迁移后必须运行一次：
+//
+//	go run ./internal/cmd/repair-lemmas -conf ./configs
+//
+// 014_word_lemma_unique.sql 要求同一词典内词元唯一，须在本命令之后执行；
+// 此后若词形还原规则变化而再次运行，新词元与同词典已有单词相同时会因唯一约束失败。
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"backend/internal/conf"
+	"backend/internal/data"
+	"backend/pkg/nlp"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// flagconf is the config flag.
+var flagconf string
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../../configs", "config path, eg: -conf config.yaml")
+}
+
+func main() {
+	flag.Parse()
+	logger := log.With(log.NewStdLogger(os.Stdout), "ts", log.DefaultTimestamp)
+	helper := log.NewHelper(logger)
+
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	d, cleanup, err := data.NewData(bc.Data)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	// 与导入、添加单词时的词元计算保持一致
+	lemmaOf := func(word string) string {
+		return nlp.LemmaKey(nlp.NormalizeSurface(word))
+	}
+	updated, err := data.NewWordRepo(d, logger).RecomputeLemmas(context.Background(), lemmaOf)
+	if err != nil {
+		helper.Errorf("repair word lemmas failed: %v", err)
+		os.Exit(1)
+	}
+	helper.Infof("word lemmas repaired, updated=%d", updated)
+}
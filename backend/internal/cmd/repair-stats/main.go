@@ -0,0 +1,65 @@
+// repair-stats 根据单词表重新计算词典的各状态计数，用于修复计数偏差
+//
+//	go run ./internal/cmd/repair-stats -conf ./configs            # 修复全部词典
+//	go run ./internal/cmd/repair-stats -conf ./configs -dict 42   # 只修复指定词典
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"backend/internal/conf"
+	"backend/internal/data"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	// flagconf is the config flag.
+	flagconf string
+	// flagdict 只修复指定词典，0 表示全部
+	flagdict int64
+)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../../configs", "config path, eg: -conf config.yaml")
+	flag.Int64Var(&flagdict, "dict", 0, "dictionary id to repair, 0 for all")
+}
+
+func main() {
+	flag.Parse()
+	logger := log.With(log.NewStdLogger(os.Stdout), "ts", log.DefaultTimestamp)
+	helper := log.NewHelper(logger)
+
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	d, cleanup, err := data.NewData(bc.Data)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	repaired, err := data.NewDictionaryRepo(d, logger).RecomputeStats(context.Background(), flagdict)
+	if err != nil {
+		helper.Errorf("repair dictionary stats failed: %v", err)
+		os.Exit(1)
+	}
+	helper.Infof("dictionary stats repaired, dict_id=%d fixed=%d", flagdict, repaired)
+}
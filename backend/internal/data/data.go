@@ -1,6 +1,7 @@
 package data
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -20,6 +21,8 @@ var ProviderSet = wire.NewSet(
 	NewWordRepo,
 	NewLearnRecordRepo,
 	NewUploadTaskRepo,
+	NewTagRepo,
+	NewLexiconRepo,
 	NewUserRepo,
 	NewRefreshTokenRepo,
 )
@@ -48,3 +51,17 @@ func NewData(c *conf.Data) (*Data, func(), error) {
 	}
 	return d, cleanup, nil
 }
+
+// inTx 在事务中执行 fn，fn 返回错误时回滚
+func (d *Data) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -5,12 +5,16 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"backend/internal/biz/entity"
 	"backend/internal/biz/repo"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/lib/pq"
 )
 
 type dictionaryRepo struct {
@@ -50,19 +54,33 @@ func (r *dictionaryRepo) Create(ctx context.Context, dict *entity.Dictionary) er
 	return nil
 }
 
+// dictColumns 词典查询字段，与 scanDictionary 的扫描顺序保持一致
+const dictColumns = `d.id, d.user_id, d.name, d.description, d.total_words, d.learned_words, d.new_words, d.learning_words, d.review_words, d.mastered_words, d.suspended_words, d.is_public, d.clone_count, d.created_at, d.updated_at, d.deleted_at`
+
+// scanDictionary 按 dictColumns 的顺序扫描词典，extra 用于接收追加的查询字段
+func scanDictionary(s rowScanner, extra ...interface{}) (*entity.Dictionary, error) {
+	dict := &entity.Dictionary{}
+	dest := []interface{}{
+		&dict.ID, &dict.UserID, &dict.Name, &dict.Description,
+		&dict.TotalWords, &dict.LearnedWords,
+		&dict.Stats.New, &dict.Stats.Learning, &dict.Stats.Review, &dict.Stats.Mastered, &dict.Stats.Suspended,
+		&dict.IsPublic, &dict.CloneCount,
+		&dict.CreatedAt, &dict.UpdatedAt, &dict.DeletedAt,
+	}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
 // GetByID 根据 ID 获取词典
 func (r *dictionaryRepo) GetByID(ctx context.Context, id int64) (*entity.Dictionary, error) {
 	query := `
-		SELECT id, user_id, name, description, total_words, learned_words, created_at, updated_at
-		FROM dictionaries
-		WHERE id = $1 AND deleted_at IS NULL
+		SELECT ` + dictColumns + `
+		FROM dictionaries d
+		WHERE d.id = $1 AND d.deleted_at IS NULL
 	`
-	dict := &entity.Dictionary{}
-	err := r.data.db.QueryRowContext(ctx, query, id).Scan(
-		&dict.ID, &dict.UserID, &dict.Name, &dict.Description,
-		&dict.TotalWords, &dict.LearnedWords,
-		&dict.CreatedAt, &dict.UpdatedAt,
-	)
+	dict, err := scanDictionary(r.data.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		r.log.Errorf("failed to get dictionary: %v", err)
 		return nil, err
@@ -73,12 +91,17 @@ func (r *dictionaryRepo) GetByID(ctx context.Context, id int64) (*entity.Diction
 // ListByUserID 获取用户的词典列表
 func (r *dictionaryRepo) ListByUserID(ctx context.Context, userID int64) ([]*entity.Dictionary, error) {
 	query := `
-		SELECT id, user_id, name, description, total_words, learned_words, created_at, updated_at
-		FROM dictionaries
-		WHERE user_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
+		SELECT ` + dictColumns + `
+		FROM dictionaries d
+		WHERE d.user_id = $1 AND d.deleted_at IS NULL
+		ORDER BY d.created_at DESC
 	`
-	rows, err := r.data.db.QueryContext(ctx, query, userID)
+	return r.listDictionaries(ctx, query, userID)
+}
+
+// listDictionaries 执行返回 dictColumns 的查询
+func (r *dictionaryRepo) listDictionaries(ctx context.Context, query string, args ...interface{}) ([]*entity.Dictionary, error) {
+	rows, err := r.data.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.log.Errorf("failed to list dictionaries: %v", err)
 		return nil, err
@@ -87,12 +110,7 @@ func (r *dictionaryRepo) ListByUserID(ctx context.Context, userID int64) ([]*ent
 
 	var dicts []*entity.Dictionary
 	for rows.Next() {
-		dict := &entity.Dictionary{}
-		err := rows.Scan(
-			&dict.ID, &dict.UserID, &dict.Name, &dict.Description,
-			&dict.TotalWords, &dict.LearnedWords,
-			&dict.CreatedAt, &dict.UpdatedAt,
-		)
+		dict, err := scanDictionary(rows)
 		if err != nil {
 			r.log.Errorf("failed to scan dictionary: %v", err)
 			continue
@@ -106,12 +124,12 @@ func (r *dictionaryRepo) ListByUserID(ctx context.Context, userID int64) ([]*ent
 func (r *dictionaryRepo) Update(ctx context.Context, dict *entity.Dictionary) error {
 	query := `
 		UPDATE dictionaries
-		SET name = $1, description = $2, updated_at = $3
-		WHERE id = $4
+		SET name = $1, description = $2, is_public = $3, updated_at = $4
+		WHERE id = $5
 	`
 	dict.UpdatedAt = time.Now()
 	_, err := r.data.db.ExecContext(ctx, query,
-		dict.Name, dict.Description, dict.UpdatedAt, dict.ID,
+		dict.Name, dict.Description, dict.IsPublic, dict.UpdatedAt, dict.ID,
 	)
 	if err != nil {
 		r.log.Errorf("failed to update dictionary: %v", err)
@@ -131,19 +149,44 @@ func (r *dictionaryRepo) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-// UpdateStats 更新词典统计信息
-func (r *dictionaryRepo) UpdateStats(ctx context.Context, id int64, totalWords, learnedWords int) error {
+// RecomputeStats 根据单词表重新计算词典的各状态计数，只更新与实际不一致的词典
+func (r *dictionaryRepo) RecomputeStats(ctx context.Context, dictID int64) (int, error) {
 	query := `
-		UPDATE dictionaries
-		SET total_words = $1, learned_words = $2, updated_at = $3
-		WHERE id = $4
+		WITH actual AS (
+			SELECT d.id,
+				COUNT(w.id) FILTER (WHERE w.status NOT IN ('learning', 'review', 'mastered', 'suspended') OR w.status IS NULL) AS new_words,
+				COUNT(w.id) FILTER (WHERE w.status = 'learning') AS learning_words,
+				COUNT(w.id) FILTER (WHERE w.status = 'review') AS review_words,
+				COUNT(w.id) FILTER (WHERE w.status = 'mastered') AS mastered_words,
+				COUNT(w.id) FILTER (WHERE w.status = 'suspended') AS suspended_words
+			FROM dictionaries d
+			LEFT JOIN words w ON w.dict_id = d.id
+			WHERE $1 = 0 OR d.id = $1
+			GROUP BY d.id
+		)
+		UPDATE dictionaries d
+		SET new_words = a.new_words,
+			learning_words = a.learning_words,
+			review_words = a.review_words,
+			mastered_words = a.mastered_words,
+			suspended_words = a.suspended_words,
+			total_words = a.new_words + a.learning_words + a.review_words + a.mastered_words + a.suspended_words,
+			learned_words = a.learning_words + a.review_words + a.mastered_words
+		FROM actual a
+		WHERE d.id = a.id
+		AND (d.new_words, d.learning_words, d.review_words, d.mastered_words, d.suspended_words, d.total_words, d.learned_words)
+			IS DISTINCT FROM
+			(a.new_words, a.learning_words, a.review_words, a.mastered_words, a.suspended_words,
+			 a.new_words + a.learning_words + a.review_words + a.mastered_words + a.suspended_words,
+			 a.learning_words + a.review_words + a.mastered_words)
 	`
-	_, err := r.data.db.ExecContext(ctx, query, totalWords, learnedWords, time.Now(), id)
+	res, err := r.data.db.ExecContext(ctx, query, dictID)
 	if err != nil {
-		r.log.Errorf("failed to update dictionary stats: %v", err)
-		return err
+		r.log.Errorf("failed to recompute dictionary stats: %v", err)
+		return 0, err
 	}
-	return nil
+	n, _ := res.RowsAffected()
+	return int(n), nil
 }
 
 // IsOwnedByUser 判断词典是否属于该用户
@@ -160,6 +203,140 @@ func (r *dictionaryRepo) IsOwnedByUser(ctx context.Context, dictID, userID int64
 	return count > 0, nil
 }
 
+// ListDeletedByUserID 获取用户回收站中的词典
+func (r *dictionaryRepo) ListDeletedByUserID(ctx context.Context, userID int64) ([]*entity.Dictionary, error) {
+	query := `
+		SELECT ` + dictColumns + `
+		FROM dictionaries d
+		WHERE d.user_id = $1 AND d.deleted_at IS NOT NULL
+		ORDER BY d.deleted_at DESC
+	`
+	return r.listDictionaries(ctx, query, userID)
+}
+
+// GetDeletedForUser 获取用户回收站中的词典
+func (r *dictionaryRepo) GetDeletedForUser(ctx context.Context, dictID, userID int64) (*entity.Dictionary, error) {
+	query := `
+		SELECT ` + dictColumns + `
+		FROM dictionaries d
+		WHERE d.id = $1 AND d.user_id = $2 AND d.deleted_at IS NOT NULL
+	`
+	dict, err := scanDictionary(r.data.db.QueryRowContext(ctx, query, dictID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Errorf("failed to get deleted dictionary: %v", err)
+		return nil, err
+	}
+	return dict, nil
+}
+
+// Restore 从回收站恢复词典
+func (r *dictionaryRepo) Restore(ctx context.Context, id int64) error {
+	query := `UPDATE dictionaries SET deleted_at = NULL, updated_at = $1 WHERE id = $2`
+	_, err := r.data.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		r.log.Errorf("failed to restore dictionary: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Purge 彻底删除已软删除的词典
+func (r *dictionaryRepo) Purge(ctx context.Context, id int64) error {
+	query := `DELETE FROM dictionaries WHERE id = $1 AND deleted_at IS NOT NULL`
+	_, err := r.data.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.log.Errorf("failed to purge dictionary: %v", err)
+		return err
+	}
+	return nil
+}
+
+// ListPublic 分页查询公开词典，keyword 匹配名称或描述，按克隆次数与创建时间排序
+func (r *dictionaryRepo) ListPublic(ctx context.Context, keyword string, limit, offset int) ([]*entity.PublicDictionary, error) {
+	query := `
+		SELECT ` + dictColumns + `, COALESCE(u.username, '')
+		FROM dictionaries d
+		LEFT JOIN users u ON u.id = d.user_id
+		WHERE d.is_public AND d.deleted_at IS NULL
+		AND ($1 = '' OR d.name ILIKE $2 OR d.description ILIKE $2)
+		ORDER BY d.clone_count DESC, d.created_at DESC, d.id DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, keyword, "%"+escapeLike(keyword)+"%", limit, offset)
+	if err != nil {
+		r.log.Errorf("failed to list public dictionaries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dicts []*entity.PublicDictionary
+	for rows.Next() {
+		pub := &entity.PublicDictionary{}
+		pub.Dictionary, err = scanDictionary(rows, &pub.OwnerName)
+		if err != nil {
+			return nil, err
+		}
+		dicts = append(dicts, pub)
+	}
+	return dicts, rows.Err()
+}
+
+// GetPublicByID 获取公开词典，不存在或未公开时返回 nil
+func (r *dictionaryRepo) GetPublicByID(ctx context.Context, id int64) (*entity.Dictionary, error) {
+	query := `
+		SELECT ` + dictColumns + `
+		FROM dictionaries d
+		WHERE d.id = $1 AND d.is_public AND d.deleted_at IS NULL
+	`
+	dict, err := scanDictionary(r.data.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Errorf("failed to get public dictionary: %v", err)
+		return nil, err
+	}
+	return dict, nil
+}
+
+// IncrementCloneCount 克隆次数加一
+func (r *dictionaryRepo) IncrementCloneCount(ctx context.Context, id int64) error {
+	query := `UPDATE dictionaries SET clone_count = clone_count + 1 WHERE id = $1`
+	_, err := r.data.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.log.Errorf("failed to increment clone count: %v", err)
+		return err
+	}
+	return nil
+}
+
+// wordColumns 单词查询字段，与 scanWord 的扫描顺序保持一致
+const wordColumns = `w.id, w.dict_id, w.word, w.lemma, w.phonetic, w.meaning, w.example, w.audio_url, w.frequency, w.provider, w.provider_version, w.notes, w.mnemonic, w.status, w.ef_factor, w.interval, w.repetitions, w.next_review_date, w.last_review_date, w.created_at, w.updated_at`
+
+// rowScanner 兼容 *sql.Row 与 *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanWord 按 wordColumns 的顺序扫描单词，extra 用于接收 wordColumns 之后追加的查询字段
+func scanWord(s rowScanner, extra ...interface{}) (*entity.Word, error) {
+	word := &entity.Word{}
+	var meaningJSON []byte
+	dest := []interface{}{
+		&word.ID, &word.DictID, &word.Word, &word.Lemma, &word.Phonetic, &meaningJSON, &word.Example,
+		&word.AudioURL, &word.Frequency, &word.Provider, &word.ProviderVersion, &word.Notes, &word.Mnemonic, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
+		&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
+	}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	json.Unmarshal(meaningJSON, &word.Meaning)
+	return word, nil
+}
+
 // wordRepo 单词仓库实现
 type wordRepo struct {
 	data *Data
@@ -174,218 +351,708 @@ func NewWordRepo(data *Data, logger log.Logger) repo.WordRepo {
 	}
 }
 
-// Create 创建单词
-func (r *wordRepo) Create(ctx context.Context, word *entity.Word) error {
-	query := `
-		INSERT INTO words (dict_id, word, phonetic, meaning, example, audio_url, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-		RETURNING id
-	`
+// insertWordQuery 插入单词，Create 与 CreateBatch 共用
+const insertWordQuery = `
+	INSERT INTO words (dict_id, word, lemma, phonetic, meaning, example, audio_url, frequency, provider, provider_version, notes, mnemonic, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+	RETURNING id
+`
+
+// insertWordArgs 按 insertWordQuery 的参数顺序展开单词字段
+func insertWordArgs(word *entity.Word) []interface{} {
 	meaningJSON, _ := json.Marshal(word.Meaning)
 	now := time.Now()
 	word.CreatedAt = now
 	word.UpdatedAt = now
-
-	err := r.data.db.QueryRowContext(ctx, query,
-		word.DictID, word.Word, word.Phonetic, meaningJSON, word.Example, word.AudioURL,
-		word.Status, word.EFFactor, word.Interval, word.Repetitions,
+	return []interface{}{
+		word.DictID, word.Word, word.Lemma, word.Phonetic, meaningJSON, word.Example, word.AudioURL,
+		word.Frequency, word.Provider, word.ProviderVersion, word.Notes, word.Mnemonic, word.Status, word.EFFactor, word.Interval, word.Repetitions,
 		word.NextReviewDate, word.LastReviewDate,
 		word.CreatedAt, word.UpdatedAt,
-	).Scan(&word.ID)
+	}
+}
 
+// Create 创建单词
+func (r *wordRepo) Create(ctx context.Context, word *entity.Word) error {
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, insertWordQuery, insertWordArgs(word)...).Scan(&word.ID); err != nil {
+			r.log.Errorf("failed to create word: %v", err)
+			return wordWriteError(err)
+		}
+		delta := statsDelta{}
+		delta.add(word.DictID, word.Status, 1)
+		return delta.apply(ctx, tx)
+	})
+}
+
+// CreateBatch 在同一事务中批量创建单词，任一失败则全部回滚
+func (r *wordRepo) CreateBatch(ctx context.Context, words []*entity.Word) error {
+	tx, err := r.data.db.BeginTx(ctx, nil)
 	if err != nil {
-		r.log.Errorf("failed to create word: %v", err)
 		return err
 	}
-	return nil
-}
+	defer tx.Rollback()
 
-// CreateBatch 批量创建单词
-func (r *wordRepo) CreateBatch(ctx context.Context, words []*entity.Word) error {
-	// 简化实现：逐个创建
+	stmt, err := tx.PrepareContext(ctx, insertWordQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	delta := statsDelta{}
 	for _, word := range words {
-		if err := r.Create(ctx, word); err != nil {
+		if err := stmt.QueryRowContext(ctx, insertWordArgs(word)...).Scan(&word.ID); err != nil {
 			r.log.Errorf("failed to create word in batch: %v", err)
-			return err
+			return wordWriteError(err)
 		}
+		delta.add(word.DictID, word.Status, 1)
 	}
-	return nil
+	if err := delta.apply(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetByID 根据 ID 获取单词
 func (r *wordRepo) GetByID(ctx context.Context, id int64) (*entity.Word, error) {
 	query := `
-		SELECT id, dict_id, word, phonetic, meaning, example, audio_url, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at
-		FROM words
-		WHERE id = $1
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.id = $1
 	`
-	word := &entity.Word{}
-	var meaningJSON []byte
-	err := r.data.db.QueryRowContext(ctx, query, id).Scan(
-		&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-		&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-		&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
-	)
+	word, err := scanWord(r.data.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		r.log.Errorf("failed to get word: %v", err)
 		return nil, err
 	}
-	json.Unmarshal(meaningJSON, &word.Meaning)
 	return word, nil
 }
 
 // GetByIDForUser 根据用户归属获取单词
 func (r *wordRepo) GetByIDForUser(ctx context.Context, id, userID int64) (*entity.Word, error) {
 	query := `
-		SELECT w.id, w.dict_id, w.word, w.phonetic, w.meaning, w.example, w.audio_url, w.status, w.ef_factor, w.interval, w.repetitions, w.next_review_date, w.last_review_date, w.created_at, w.updated_at
+		SELECT ` + wordColumns + `
 		FROM words w
 		INNER JOIN dictionaries d ON d.id = w.dict_id
 		WHERE w.id = $1 AND d.user_id = $2 AND d.deleted_at IS NULL
 	`
-	word := &entity.Word{}
-	var meaningJSON []byte
-	err := r.data.db.QueryRowContext(ctx, query, id, userID).Scan(
-		&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-		&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-		&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
-	)
+	word, err := scanWord(r.data.db.QueryRowContext(ctx, query, id, userID))
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
-	json.Unmarshal(meaningJSON, &word.Meaning)
 	return word, nil
 }
 
 // GetByDictIDAndWord 根据词典 ID 和单词获取
 func (r *wordRepo) GetByDictIDAndWord(ctx context.Context, dictID int64, wordStr string) (*entity.Word, error) {
 	query := `
-		SELECT id, dict_id, word, phonetic, meaning, example, audio_url, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at
-		FROM words
-		WHERE dict_id = $1 AND word = $2
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.dict_id = $1 AND w.word = $2
 	`
-	word := &entity.Word{}
-	var meaningJSON []byte
-	err := r.data.db.QueryRowContext(ctx, query, dictID, wordStr).Scan(
-		&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-		&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-		&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
-	)
+	return scanWord(r.data.db.QueryRowContext(ctx, query, dictID, wordStr))
+}
+
+// GetByDictIDAndLemma 根据词典 ID 和词元获取（导入去重）
+func (r *wordRepo) GetByDictIDAndLemma(ctx context.Context, dictID int64, lemma string) (*entity.Word, error) {
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.dict_id = $1 AND w.lemma = $2
+		ORDER BY w.id ASC
+		LIMIT 1
+	`
+	word, err := scanWord(r.data.db.QueryRowContext(ctx, query, dictID, lemma))
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return word, nil
+}
+
+// GetByUserAndLemma 根据用户和词元获取（跨词典复用），优先返回写过笔记或助记的单词
+func (r *wordRepo) GetByUserAndLemma(ctx context.Context, userID int64, lemma string) (*entity.Word, error) {
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		INNER JOIN dictionaries d ON d.id = w.dict_id
+		WHERE d.user_id = $1 AND d.deleted_at IS NULL AND w.lemma = $2
+		ORDER BY (w.notes <> '' OR w.mnemonic <> '') DESC, w.id ASC
+		LIMIT 1
+	`
+	word, err := scanWord(r.data.db.QueryRowContext(ctx, query, userID, lemma))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
-	json.Unmarshal(meaningJSON, &word.Meaning)
 	return word, nil
 }
 
 // GetByUserAndWord 根据用户和单词获取（跨词典复用）
 func (r *wordRepo) GetByUserAndWord(ctx context.Context, userID int64, wordStr string) (*entity.Word, error) {
 	query := `
-		SELECT w.id, w.dict_id, w.word, w.phonetic, w.meaning, w.example, w.audio_url, w.status, w.ef_factor, w.interval, w.repetitions, w.next_review_date, w.last_review_date, w.created_at, w.updated_at
+		SELECT ` + wordColumns + `
 		FROM words w
 		INNER JOIN dictionaries d ON d.id = w.dict_id
 		WHERE d.user_id = $1 AND d.deleted_at IS NULL AND w.word = $2
 		ORDER BY w.id ASC
 		LIMIT 1
 	`
-	word := &entity.Word{}
-	var meaningJSON []byte
-	err := r.data.db.QueryRowContext(ctx, query, userID, wordStr).Scan(
-		&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-		&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-		&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
-	)
+	word, err := scanWord(r.data.db.QueryRowContext(ctx, query, userID, wordStr))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	json.Unmarshal(meaningJSON, &word.Meaning)
 	return word, nil
 }
 
-// ListByDictID 获取词典的单词列表
-func (r *wordRepo) ListByDictID(ctx context.Context, dictID int64, offset, limit int) ([]*entity.Word, error) {
+// ListKnownWords 返回给定词元中用户已经学会（复习中或已掌握）的部分
+func (r *wordRepo) ListKnownWords(ctx context.Context, userID int64, lemmas []string) ([]string, error) {
 	query := `
-		SELECT id, dict_id, word, phonetic, meaning, example, audio_url, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at
-		FROM words
-		WHERE dict_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		SELECT DISTINCT w.lemma
+		FROM words w
+		INNER JOIN dictionaries d ON d.id = w.dict_id
+		WHERE d.user_id = $1 AND d.deleted_at IS NULL
+		AND w.status IN ('review', 'mastered')
+		AND w.lemma = ANY($2)
 	`
-	rows, err := r.data.db.QueryContext(ctx, query, dictID, limit, offset)
+	known, err := r.queryLemmas(ctx, query, userID, lemmas)
+	if err != nil {
+		r.log.Errorf("failed to list known words: %v", err)
+	}
+	return known, err
+}
+
+// ListLemmasInDict 返回给定词元中词典已包含的部分
+func (r *wordRepo) ListLemmasInDict(ctx context.Context, dictID int64, lemmas []string) ([]string, error) {
+	query := `SELECT DISTINCT w.lemma FROM words w WHERE w.dict_id = $1 AND w.lemma = ANY($2)`
+	return r.queryLemmas(ctx, query, dictID, lemmas)
+}
+
+// ListLemmasForUser 返回给定词元中用户任一词典已包含的部分（可跨词典复用）
+func (r *wordRepo) ListLemmasForUser(ctx context.Context, userID int64, lemmas []string) ([]string, error) {
+	query := `
+		SELECT DISTINCT w.lemma
+		FROM words w
+		INNER JOIN dictionaries d ON d.id = w.dict_id
+		WHERE d.user_id = $1 AND d.deleted_at IS NULL AND w.lemma = ANY($2)
+	`
+	return r.queryLemmas(ctx, query, userID, lemmas)
+}
+
+// queryLemmas 执行以 (id, lemmas) 为参数、返回单列词元的查询
+func (r *wordRepo) queryLemmas(ctx context.Context, query string, id int64, lemmas []string) ([]string, error) {
+	if len(lemmas) == 0 {
+		return nil, nil
+	}
+	rows, err := r.data.db.QueryContext(ctx, query, id, pq.Array(lemmas))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var words []*entity.Word
+	var result []string
 	for rows.Next() {
-		word := &entity.Word{}
-		var meaningJSON []byte
-		err := rows.Scan(
-			&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-			&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-			&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
+		var w string
+		if err := rows.Scan(&w); err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, rows.Err()
+}
+
+// wordSortColumns 各排序方式对应的排序键表达式与游标参数类型，NULL 与未知值统一映射到末尾
+var wordSortColumns = map[entity.WordSort]struct {
+	expr string
+	cast string
+}{
+	entity.WordSortAlpha:      {"w.lemma", "text"},
+	entity.WordSortNextReview: {"COALESCE(w.next_review_date, DATE '9999-12-31')", "date"},
+	entity.WordSortEFFactor:   {"w.ef_factor", "numeric"},
+	entity.WordSortFrequency:  {"(CASE WHEN w.frequency > 0 THEN w.frequency ELSE 2147483647 END)", "int"},
+}
+
+// SearchWords 在用户全部词典中检索单词、音标、释义与例句
+// 全文匹配（前缀）、子串匹配与词元模糊匹配任一命中即返回，按相关度降序
+func (r *wordRepo) SearchWords(ctx context.Context, userID int64, q *entity.WordSearchQuery) ([]*entity.WordSearchHit, error) {
+	query := `
+		WITH q AS (
+			SELECT to_tsquery('simple', $2) || to_tsquery('english', $2) AS tsq, $3::text AS term
 		)
+		SELECT ` + wordColumns + `, d.name,
+			ts_rank(w.search_vector, q.tsq)
+			+ similarity(w.lemma, q.term)
+			+ CASE WHEN w.lemma = q.term THEN 1 ELSE 0 END AS score
+		FROM words w
+		INNER JOIN dictionaries d ON d.id = w.dict_id
+		CROSS JOIN q
+		WHERE d.user_id = $1 AND d.deleted_at IS NULL
+		AND (w.search_vector @@ q.tsq OR w.search_text LIKE $4 OR w.lemma % q.term)
+		ORDER BY score DESC, w.id ASC
+		LIMIT $5 OFFSET $6
+	`
+	rows, err := r.data.db.QueryContext(ctx, query,
+		userID, q.TSQuery, q.Term, "%"+escapeLike(q.Term)+"%", q.Limit, q.Offset,
+	)
+	if err != nil {
+		r.log.Errorf("failed to search words: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*entity.WordSearchHit
+	for rows.Next() {
+		hit := &entity.WordSearchHit{}
+		hit.Word, err = scanWord(rows, &hit.DictName, &hit.Score)
 		if err != nil {
-			continue
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// ListWords 按条件分页查询单词（游标分页，以排序键 + ID 定位）
+func (r *wordRepo) ListWords(ctx context.Context, f *entity.WordFilter) ([]*entity.Word, error) {
+	conds := []string{"w.dict_id = $1"}
+	args := []interface{}{f.DictID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(f.Statuses) > 0 {
+		conds = append(conds, "w.status = ANY("+arg(pq.Array(f.Statuses))+")")
+	}
+	if f.MinEF != nil {
+		conds = append(conds, "w.ef_factor >= "+arg(*f.MinEF))
+	}
+	if f.MaxEF != nil {
+		conds = append(conds, "w.ef_factor <= "+arg(*f.MaxEF))
+	}
+	if f.DueBefore != nil {
+		conds = append(conds, "w.next_review_date <= "+arg(*f.DueBefore))
+	}
+	if f.DueAfter != nil {
+		conds = append(conds, "w.next_review_date >= "+arg(*f.DueAfter))
+	}
+	if f.Prefix != "" {
+		conds = append(conds, "w.lemma LIKE "+arg(escapeLike(f.Prefix)+"%"))
+	}
+	if f.Tag != "" {
+		conds = append(conds, hasTagCondition(arg(f.Tag)))
+	}
+	if f.HasFailedLookup != nil {
+		missing := emptyMeaningCondition
+		if !*f.HasFailedLookup {
+			missing = "NOT " + missing
+		}
+		conds = append(conds, missing)
+	}
+
+	cmp, dir := ">", "ASC"
+	if f.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	orderBy := "w.id " + dir
+	if col, ok := wordSortColumns[f.Sort]; ok {
+		if f.AfterID > 0 {
+			conds = append(conds, fmt.Sprintf("(%s, w.id) %s (%s::%s, %s)", col.expr, cmp, arg(f.AfterKey), col.cast, arg(f.AfterID)))
+		}
+		orderBy = col.expr + " " + dir + ", " + orderBy
+	} else if f.AfterID > 0 {
+		conds = append(conds, "w.id "+cmp+" "+arg(f.AfterID))
+	}
+
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE ` + strings.Join(conds, " AND ") + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + arg(f.Limit)
+	rows, err := r.data.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Errorf("failed to list words: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []*entity.Word
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return nil, err
 		}
-		json.Unmarshal(meaningJSON, &word.Meaning)
 		words = append(words, word)
 	}
-	return words, nil
+	return words, rows.Err()
 }
 
-// CountByDictID 统计词典单词数
-func (r *wordRepo) CountByDictID(ctx context.Context, dictID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM words WHERE dict_id = $1`
-	var count int
-	err := r.data.db.QueryRowContext(ctx, query, dictID).Scan(&count)
+// CountByStatus 按学习状态统计词典单词数，tag 非空时只统计带该标签的单词
+func (r *wordRepo) CountByStatus(ctx context.Context, dictID int64, tag string) (map[string]int, error) {
+	query := `
+		SELECT w.status, COUNT(*)
+		FROM words w
+		WHERE w.dict_id = $1 AND ($2 = '' OR ` + hasTagCondition("$2") + `)
+		GROUP BY w.status
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, dictID, tag)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return count, nil
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
 }
 
-// Update 更新单词
+// emptyMeaningCondition 释义为空的条件，与 meaning.Meaning.IsEmpty 一致：
+// 没有任何带英文释义或中文翻译的义项，只有考试标签也算为空
+const emptyMeaningCondition = `(w.meaning IS NULL OR NOT jsonb_path_exists(w.meaning,
+		'$.senses[*] ? (exists(@.definitions[*]) || exists(@.translations[*]))'))`
+
+// hasTagCondition 单词带有指定名称标签的条件，param 为标签名占位符
+func hasTagCondition(param string) string {
+	return `EXISTS (
+			SELECT 1 FROM word_tags wt INNER JOIN tags t ON t.id = wt.tag_id
+			WHERE wt.word_id = w.id AND t.name = ` + param + `
+		)`
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// StreamByDictID 按 ID 顺序逐行读取词典单词，避免一次性载入内存
+func (r *wordRepo) StreamByDictID(ctx context.Context, dictID int64, fn func(*entity.Word) error) error {
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.dict_id = $1
+		ORDER BY w.id ASC
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, dictID)
+	if err != nil {
+		r.log.Errorf("failed to stream words: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(word); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// recomputeLemmaBatch 重新计算词元时每批处理的单词数
+const recomputeLemmaBatch = 1000
+
+// RecomputeLemmas 按 ID 分批重新计算词元，每批用一条语句更新
+func (r *wordRepo) RecomputeLemmas(ctx context.Context, lemmaOf func(word string) string) (int, error) {
+	selectQuery := `SELECT id, word, lemma FROM words WHERE id > $1 ORDER BY id ASC LIMIT $2`
+	updateQuery := `
+		UPDATE words w
+		SET lemma = v.lemma
+		FROM unnest($1::bigint[], $2::text[]) AS v(id, lemma)
+		WHERE w.id = v.id
+	`
+	var afterID int64
+	updated := 0
+	for {
+		rows, err := r.data.db.QueryContext(ctx, selectQuery, afterID, recomputeLemmaBatch)
+		if err != nil {
+			r.log.Errorf("failed to list words for lemma recompute: %v", err)
+			return updated, err
+		}
+		var ids []int64
+		var lemmas []string
+		scanned := 0
+		for rows.Next() {
+			var id int64
+			var word, lemma string
+			if err := rows.Scan(&id, &word, &lemma); err != nil {
+				rows.Close()
+				return updated, err
+			}
+			scanned++
+			afterID = id
+			if key := lemmaOf(word); key != "" && key != lemma {
+				ids = append(ids, id)
+				lemmas = append(lemmas, key)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return updated, err
+		}
+		if len(ids) > 0 {
+			if _, err := r.data.db.ExecContext(ctx, updateQuery, pq.Array(ids), pq.Array(lemmas)); err != nil {
+				r.log.Errorf("failed to update lemmas: %v", err)
+				return updated, err
+			}
+			updated += len(ids)
+		}
+		if scanned < recomputeLemmaBatch {
+			return updated, nil
+		}
+	}
+}
+
+// Update 更新单词的释义与记忆状态（不含笔记与助记）
 func (r *wordRepo) Update(ctx context.Context, word *entity.Word) error {
 	query := `
 		UPDATE words
-		SET phonetic = $1, meaning = $2, example = $3, audio_url = $4, status = $5, ef_factor = $6, interval = $7, repetitions = $8, next_review_date = $9, last_review_date = $10, updated_at = $11
-		WHERE id = $12
+		SET phonetic = $1, meaning = $2, example = $3, audio_url = $4, provider = $5, provider_version = $6, status = $7, ef_factor = $8, interval = $9, repetitions = $10, next_review_date = $11, last_review_date = $12, updated_at = $13
+		WHERE id = $14
 	`
 	meaningJSON, _ := json.Marshal(word.Meaning)
 	word.UpdatedAt = time.Now()
 
-	_, err := r.data.db.ExecContext(ctx, query,
-		word.Phonetic, meaningJSON, word.Example, word.AudioURL,
-		word.Status, word.EFFactor, word.Interval, word.Repetitions,
-		word.NextReviewDate, word.LastReviewDate, word.UpdatedAt, word.ID,
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		dictID, oldStatus, err := lockWordStatus(ctx, tx, word.ID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query,
+			word.Phonetic, meaningJSON, word.Example, word.AudioURL, word.Provider, word.ProviderVersion,
+			word.Status, word.EFFactor, word.Interval, word.Repetitions,
+			word.NextReviewDate, word.LastReviewDate, word.UpdatedAt, word.ID,
+		)
+		if err != nil {
+			r.log.Errorf("failed to update word: %v", err)
+			return err
+		}
+		if oldStatus == word.Status {
+			return nil
+		}
+		delta := statsDelta{}
+		delta.move(dictID, oldStatus, dictID, word.Status)
+		return delta.apply(ctx, tx)
+	})
+}
+
+// wordWriteError 将违反词元唯一约束的错误转换为 repo.ErrDuplicateWord
+func wordWriteError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "uq_words_dict_lemma" {
+		return repo.ErrDuplicateWord
+	}
+	return err
+}
+
+// lockWordStatus 锁定单词行并返回其当前词典与状态
+func lockWordStatus(ctx context.Context, tx *sql.Tx, id int64) (int64, string, error) {
+	var (
+		dictID int64
+		status sql.NullString
 	)
+	err := tx.QueryRowContext(ctx, `SELECT dict_id, status FROM words WHERE id = $1 FOR UPDATE`, id).Scan(&dictID, &status)
+	return dictID, status.String, err
+}
+
+// UpdateNotes 更新单词的个人笔记与助记，Update 不会修改这两个字段
+func (r *wordRepo) UpdateNotes(ctx context.Context, id int64, notes, mnemonic string) error {
+	query := `UPDATE words SET notes = $1, mnemonic = $2, updated_at = $3 WHERE id = $4`
+	_, err := r.data.db.ExecContext(ctx, query, notes, mnemonic, time.Now(), id)
 	if err != nil {
-		r.log.Errorf("failed to update word: %v", err)
+		r.log.Errorf("failed to update word notes: %v", err)
 		return err
 	}
 	return nil
 }
 
-// GetTodayTasks 获取今日学习任务
-func (r *wordRepo) GetTodayTasks(ctx context.Context, dictID int64, limit int) ([]*entity.Word, error) {
+// Delete 删除单词（学习记录级联删除）
+func (r *wordRepo) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM words WHERE id = $1 RETURNING dict_id, status`
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		var (
+			dictID int64
+			status sql.NullString
+		)
+		err := tx.QueryRowContext(ctx, query, id).Scan(&dictID, &status)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			r.log.Errorf("failed to delete word: %v", err)
+			return err
+		}
+		delta := statsDelta{}
+		delta.add(dictID, status.String, -1)
+		return delta.apply(ctx, tx)
+	})
+}
+
+// MoveToDict 将单词移动到另一词典，保留记忆状态
+func (r *wordRepo) MoveToDict(ctx context.Context, id, dictID int64) error {
+	query := `UPDATE words SET dict_id = $1, updated_at = $2 WHERE id = $3`
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		fromDictID, status, err := lockWordStatus(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, dictID, time.Now(), id); err != nil {
+			r.log.Errorf("failed to move word: %v", err)
+			return wordWriteError(err)
+		}
+		delta := statsDelta{}
+		delta.move(fromDictID, status, dictID, status)
+		return delta.apply(ctx, tx)
+	})
+}
+
+// MoveManyToDict 将多个单词移动到另一词典，保留记忆状态
+func (r *wordRepo) MoveManyToDict(ctx context.Context, ids []int64, dictID int64) error {
 	query := `
-		SELECT id, dict_id, word, phonetic, meaning, example, audio_url, status, ef_factor, interval, repetitions, next_review_date, last_review_date, created_at, updated_at
-		FROM words
-		WHERE dict_id = $1
+		UPDATE words w SET dict_id = $1, updated_at = $2
+		FROM (SELECT id, dict_id, status FROM words WHERE id = ANY($3) FOR UPDATE) old
+		WHERE w.id = old.id
+		RETURNING old.dict_id, old.status
+	`
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, dictID, time.Now(), pq.Array(ids))
+		if err != nil {
+			r.log.Errorf("failed to move words: %v", err)
+			return wordWriteError(err)
+		}
+		defer rows.Close()
+
+		delta := statsDelta{}
+		for rows.Next() {
+			var (
+				fromDictID int64
+				status     sql.NullString
+			)
+			if err := rows.Scan(&fromDictID, &status); err != nil {
+				return err
+			}
+			delta.move(fromDictID, status.String, dictID, status.String)
+		}
+		if err := rows.Err(); err != nil {
+			return wordWriteError(err)
+		}
+		return delta.apply(ctx, tx)
+	})
+}
+
+// MergeDuplicate 在同一事务中合并重复单词：转移学习记录、删除重复单词、更新并移动保留的单词
+func (r *wordRepo) MergeDuplicate(ctx context.Context, keepID, dropID, dictID int64, notes, mnemonic string) error {
+	return r.data.inTx(ctx, func(tx *sql.Tx) error {
+		// 按 ID 顺序加锁，避免并发合并时死锁
+		type lockedWord struct {
+			dictID int64
+			status string
+		}
+		locked := make(map[int64]lockedWord, 2)
+		for _, id := range []int64{min(keepID, dropID), max(keepID, dropID)} {
+			wordDictID, status, err := lockWordStatus(ctx, tx, id)
+			if err != nil {
+				r.log.Errorf("failed to lock word for merge: %v", err)
+				return err
+			}
+			locked[id] = lockedWord{dictID: wordDictID, status: status}
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE learn_records SET word_id = $1 WHERE word_id = $2`, keepID, dropID); err != nil {
+			r.log.Errorf("failed to reassign learn records: %v", err)
+			return err
+		}
+		// 先删除重复单词，保留的单词才能移入同一词典
+		if _, err := tx.ExecContext(ctx, `DELETE FROM words WHERE id = $1`, dropID); err != nil {
+			r.log.Errorf("failed to delete duplicate word: %v", err)
+			return err
+		}
+		query := `UPDATE words SET dict_id = $1, notes = $2, mnemonic = $3, updated_at = $4 WHERE id = $5`
+		if _, err := tx.ExecContext(ctx, query, dictID, notes, mnemonic, time.Now(), keepID); err != nil {
+			r.log.Errorf("failed to update merged word: %v", err)
+			return wordWriteError(err)
+		}
+		keep, drop := locked[keepID], locked[dropID]
+		delta := statsDelta{}
+		delta.add(drop.dictID, drop.status, -1)
+		delta.move(keep.dictID, keep.status, dictID, keep.status)
+		return delta.apply(ctx, tx)
+	})
+}
+
+// CopyToDict 将词典全部单词复制到另一词典，只复制单词与释义，记忆状态重置为新词，不复制原主人的笔记与助记
+func (r *wordRepo) CopyToDict(ctx context.Context, fromDictID, toDictID int64) (int, error) {
+	query := `
+		INSERT INTO words (dict_id, word, lemma, phonetic, meaning, example, audio_url, frequency, provider, provider_version, status, interval, repetitions, created_at, updated_at)
+		SELECT $1, w.word, w.lemma, w.phonetic, w.meaning, w.example, w.audio_url, w.frequency, w.provider, w.provider_version, 'new', 0, 0, $2, $2
+		FROM words w
+		WHERE w.dict_id = $3
+		ORDER BY w.id
+	`
+	// ef_factor 使用表默认值 2.50
+	var n int64
+	err := r.data.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, toDictID, time.Now(), fromDictID)
+		if err != nil {
+			r.log.Errorf("failed to copy words: %v", err)
+			return err
+		}
+		n, _ = res.RowsAffected()
+		delta := statsDelta{}
+		delta.add(toDictID, "new", int(n))
+		return delta.apply(ctx, tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// GetTodayTasks 获取今日学习任务，tag 非空时只返回带该标签的单词
+func (r *wordRepo) GetTodayTasks(ctx context.Context, dictID int64, tag string, limit int) ([]*entity.Word, error) {
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.dict_id = $1
 		AND (
-			status = 'new'
-			OR (next_review_date <= CURRENT_DATE AND status IN ('learning', 'review'))
+			w.status = 'new'
+			OR (w.next_review_date <= CURRENT_DATE AND w.status IN ('learning', 'review'))
 		)
+		AND ($3 = '' OR ` + hasTagCondition("$3") + `)
 		ORDER BY 
-			CASE WHEN next_review_date <= CURRENT_DATE THEN 0 ELSE 1 END,
-			next_review_date ASC
+			CASE WHEN w.next_review_date <= CURRENT_DATE THEN 0 ELSE 1 END,
+			w.next_review_date ASC
 		LIMIT $2
 	`
-	rows, err := r.data.db.QueryContext(ctx, query, dictID, limit)
+	rows, err := r.data.db.QueryContext(ctx, query, dictID, limit, tag)
 	if err != nil {
 		return nil, err
 	}
@@ -393,32 +1060,26 @@ func (r *wordRepo) GetTodayTasks(ctx context.Context, dictID int64, limit int) (
 
 	var words []*entity.Word
 	for rows.Next() {
-		word := &entity.Word{}
-		var meaningJSON []byte
-		err := rows.Scan(
-			&word.ID, &word.DictID, &word.Word, &word.Phonetic, &meaningJSON, &word.Example,
-			&word.AudioURL, &word.Status, &word.EFFactor, &word.Interval, &word.Repetitions,
-			&word.NextReviewDate, &word.LastReviewDate, &word.CreatedAt, &word.UpdatedAt,
-		)
+		word, err := scanWord(rows)
 		if err != nil {
 			continue
 		}
-		json.Unmarshal(meaningJSON, &word.Meaning)
 		words = append(words, word)
 	}
 	return words, nil
 }
 
 // CountReviewToday 统计今日待复习数
-func (r *wordRepo) CountReviewToday(ctx context.Context, dictID int64) (int, error) {
+func (r *wordRepo) CountReviewToday(ctx context.Context, dictID int64, tag string) (int, error) {
 	query := `
-		SELECT COUNT(*) FROM words
-		WHERE dict_id = $1
-		AND next_review_date <= CURRENT_DATE
-		AND status IN ('learning', 'review')
+		SELECT COUNT(*) FROM words w
+		WHERE w.dict_id = $1
+		AND w.next_review_date <= CURRENT_DATE
+		AND w.status IN ('learning', 'review')
+		AND ($2 = '' OR ` + hasTagCondition("$2") + `)
 	`
 	var count int
-	err := r.data.db.QueryRowContext(ctx, query, dictID).Scan(&count)
+	err := r.data.db.QueryRowContext(ctx, query, dictID, tag).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -426,10 +1087,14 @@ func (r *wordRepo) CountReviewToday(ctx context.Context, dictID int64) (int, err
 }
 
 // CountNewWords 统计新词数
-func (r *wordRepo) CountNewWords(ctx context.Context, dictID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM words WHERE dict_id = $1 AND status = 'new'`
+func (r *wordRepo) CountNewWords(ctx context.Context, dictID int64, tag string) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM words w
+		WHERE w.dict_id = $1 AND w.status = 'new'
+		AND ($2 = '' OR ` + hasTagCondition("$2") + `)
+	`
 	var count int
-	err := r.data.db.QueryRowContext(ctx, query, dictID).Scan(&count)
+	err := r.data.db.QueryRowContext(ctx, query, dictID, tag).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
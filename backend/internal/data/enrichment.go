@@ -0,0 +1,141 @@
+// internal/data/enrichment.go
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend/internal/biz/entity"
+)
+
+// 后台补全的筛选条件，$1/$2 为 sourceArrays 的结果
+const (
+	// missingFieldsCondition 缺少音标、例句或释义
+	missingFieldsCondition = `(w.phonetic = '' OR w.example = '' OR ` + emptyMeaningCondition + `)`
+	// staleProviderCondition 释义来自的提供方已停用或版本已调整
+	staleProviderCondition = `(w.provider <> '' AND (w.provider, w.provider_version) NOT IN (SELECT * FROM unnest($1::text[], $2::int[])))`
+)
+
+// enrichmentCondition 待补全且 $3 之后未尝试过的单词
+const enrichmentCondition = `
+	(` + missingFieldsCondition + ` OR ` + staleProviderCondition + `)
+	AND (w.enriched_at IS NULL OR w.enriched_at < $3)
+`
+
+// ListEnrichmentDictIDs 返回存在待补全单词的词典
+func (r *wordRepo) ListEnrichmentDictIDs(ctx context.Context, sources []entity.LexiconSource, retryBefore time.Time) ([]int64, error) {
+	query := `
+		SELECT d.id
+		FROM dictionaries d
+		WHERE d.deleted_at IS NULL AND EXISTS (
+			SELECT 1 FROM words w
+			WHERE w.dict_id = d.id AND ` + enrichmentCondition + `
+		)
+		ORDER BY d.id ASC
+	`
+	providers, versions := sourceArrays(sources)
+	rows, err := r.data.db.QueryContext(ctx, query, providers, versions, retryBefore)
+	if err != nil {
+		r.log.Errorf("failed to list enrichment dictionaries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListEnrichmentCandidates 按 ID 游标返回词典中待补全的单词
+func (r *wordRepo) ListEnrichmentCandidates(ctx context.Context, dictID int64, sources []entity.LexiconSource, retryBefore time.Time, afterID int64, limit int) ([]*entity.Word, error) {
+	query := `
+		SELECT ` + wordColumns + `
+		FROM words w
+		JOIN dictionaries d ON d.id = w.dict_id AND d.deleted_at IS NULL
+		WHERE w.dict_id = $4 AND w.id > $5 AND ` + enrichmentCondition + `
+		ORDER BY w.id ASC
+		LIMIT $6
+	`
+	providers, versions := sourceArrays(sources)
+	rows, err := r.data.db.QueryContext(ctx, query, providers, versions, retryBefore, dictID, afterID, limit)
+	if err != nil {
+		r.log.Errorf("failed to list enrichment candidates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []*entity.Word
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// CountEnrichment 统计词典中待补全的单词
+func (r *wordRepo) CountEnrichment(ctx context.Context, dictID int64, sources []entity.LexiconSource) (*entity.EnrichmentStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE ` + missingFieldsCondition + `),
+			COUNT(*) FILTER (WHERE ` + emptyMeaningCondition + `),
+			COUNT(*) FILTER (WHERE ` + staleProviderCondition + `)
+		FROM words w
+		WHERE w.dict_id = $3
+	`
+	providers, versions := sourceArrays(sources)
+	stats := &entity.EnrichmentStats{}
+	err := r.data.db.QueryRowContext(ctx, query, providers, versions, dictID).Scan(
+		&stats.TotalWords, &stats.MissingWords, &stats.FailedLookupWords, &stats.StaleWords,
+	)
+	if err != nil {
+		r.log.Errorf("failed to count enrichment: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// UpdateEnrichment 只更新翻译器给出的字段，单词在 readAt 之后被修改过时跳过
+func (r *wordRepo) UpdateEnrichment(ctx context.Context, word *entity.Word, readAt time.Time) (bool, error) {
+	query := `
+		UPDATE words
+		SET phonetic = $1, meaning = $2, example = $3, frequency = $4, provider = $5, provider_version = $6,
+			enriched_at = $7, updated_at = $7
+		WHERE id = $8 AND updated_at = $9
+	`
+	meaningJSON, _ := json.Marshal(word.Meaning)
+	now := time.Now()
+	res, err := r.data.db.ExecContext(ctx, query,
+		word.Phonetic, meaningJSON, word.Example, word.Frequency, word.Provider, word.ProviderVersion,
+		now, word.ID, readAt,
+	)
+	if err != nil {
+		r.log.Errorf("failed to update word enrichment: %v", err)
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return false, nil
+	}
+	word.UpdatedAt = now
+	return true, nil
+}
+
+// MarkEnrichmentAttempted 只记录补全时间
+func (r *wordRepo) MarkEnrichmentAttempted(ctx context.Context, id int64) error {
+	_, err := r.data.db.ExecContext(ctx, `UPDATE words SET enriched_at = $1 WHERE id = $2`, time.Now(), id)
+	if err != nil {
+		r.log.Errorf("failed to mark word enrichment: %v", err)
+	}
+	return err
+}
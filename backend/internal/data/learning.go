@@ -9,6 +9,7 @@ import (
 	"backend/internal/biz/repo"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/lib/pq"
 )
 
 type learnRecordRepo struct {
@@ -47,7 +48,7 @@ func (r *learnRecordRepo) Create(ctx context.Context, record *entity.LearnRecord
 	return nil
 }
 
-// ListByWordID 获取单词的学习记录
+// ListByWordID 获取单词的学习记录，limit <= 0 表示不限制条数
 func (r *learnRecordRepo) ListByWordID(ctx context.Context, wordID int64, limit int) ([]*entity.LearnRecord, error) {
 	query := `
 		SELECT id, word_id, quality, time_spent, ef_factor_before, ef_factor_after, interval_before, interval_after, created_at
@@ -56,7 +57,12 @@ func (r *learnRecordRepo) ListByWordID(ctx context.Context, wordID int64, limit
 		ORDER BY created_at DESC
 		LIMIT $2
 	`
-	rows, err := r.data.db.QueryContext(ctx, query, wordID, limit)
+	// LIMIT NULL 等价于不限制
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+	rows, err := r.data.db.QueryContext(ctx, query, wordID, limitArg)
 	if err != nil {
 		return nil, err
 	}
@@ -78,3 +84,38 @@ func (r *learnRecordRepo) ListByWordID(ctx context.Context, wordID int64, limit
 	}
 	return records, nil
 }
+
+// ListByWordIDs 批量获取多个单词的学习记录（用于导出，避免逐词查询）
+func (r *learnRecordRepo) ListByWordIDs(ctx context.Context, wordIDs []int64) (map[int64][]*entity.LearnRecord, error) {
+	result := make(map[int64][]*entity.LearnRecord, len(wordIDs))
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT id, word_id, quality, time_spent, ef_factor_before, ef_factor_after, interval_before, interval_after, created_at
+		FROM learn_records
+		WHERE word_id = ANY($1)
+		ORDER BY word_id, created_at DESC
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(wordIDs))
+	if err != nil {
+		r.log.Errorf("failed to list learn records by word ids: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record := &entity.LearnRecord{}
+		err := rows.Scan(
+			&record.ID, &record.WordID, &record.Quality, &record.TimeSpent,
+			&record.EFFactorBefore, &record.EFFactorAfter,
+			&record.IntervalBefore, &record.IntervalAfter,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[record.WordID] = append(result[record.WordID], record)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,142 @@
+// internal/data/lexicon.go
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"backend/internal/biz/entity"
+	"backend/internal/biz/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/lib/pq"
+)
+
+type lexiconRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewLexiconRepo 创建全局词库缓存仓库实例
+func NewLexiconRepo(data *Data, logger log.Logger) repo.LexiconRepo {
+	return &lexiconRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// sourceArrays 将提供方条件展开为两个并行数组，配合 unnest 使用
+func sourceArrays(sources []entity.LexiconSource) (interface{}, interface{}) {
+	providers := make([]string, 0, len(sources))
+	versions := make([]int64, 0, len(sources))
+	for _, s := range sources {
+		providers = append(providers, s.Provider)
+		versions = append(versions, int64(s.Version))
+	}
+	return pq.Array(providers), pq.Array(versions)
+}
+
+// validLexiconCondition 未过期且版本与当前配置一致，$2/$3 为 sourceArrays 的结果
+const validLexiconCondition = `
+	l.expires_at > NOW()
+	AND (l.provider, l.version) IN (SELECT * FROM unnest($2::text[], $3::int[]))
+`
+
+// Get 按 sources 的顺序返回第一个有效缓存
+func (r *lexiconRepo) Get(ctx context.Context, word string, sources []entity.LexiconSource) (*entity.LexiconEntry, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	query := `
+		SELECT l.id, l.word, l.provider, l.version, l.phonetic, l.meaning, l.example, l.frequency, l.expires_at, l.created_at, l.updated_at
+		FROM lexicon l
+		WHERE l.word = $1 AND ` + validLexiconCondition + `
+		ORDER BY array_position($2::text[], l.provider::text)
+		LIMIT 1
+	`
+	providers, versions := sourceArrays(sources)
+	entry := &entity.LexiconEntry{}
+	var meaningJSON []byte
+	err := r.data.db.QueryRowContext(ctx, query, word, providers, versions).Scan(
+		&entry.ID, &entry.Word, &entry.Provider, &entry.Version, &entry.Phonetic, &meaningJSON,
+		&entry.Example, &entry.Frequency, &entry.ExpiresAt, &entry.CreatedAt, &entry.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Errorf("failed to get lexicon entry: %v", err)
+		return nil, err
+	}
+	json.Unmarshal(meaningJSON, &entry.Meaning)
+	return entry, nil
+}
+
+// ListCached 返回给定单词中存在有效缓存的部分
+func (r *lexiconRepo) ListCached(ctx context.Context, words []string, sources []entity.LexiconSource) ([]string, error) {
+	if len(words) == 0 || len(sources) == 0 {
+		return nil, nil
+	}
+	query := `
+		SELECT DISTINCT l.word
+		FROM lexicon l
+		WHERE l.word = ANY($1) AND ` + validLexiconCondition
+	providers, versions := sourceArrays(sources)
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(words), providers, versions)
+	if err != nil {
+		r.log.Errorf("failed to list cached lexicon words: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cached []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		cached = append(cached, word)
+	}
+	return cached, rows.Err()
+}
+
+// Put 写入或覆盖缓存
+func (r *lexiconRepo) Put(ctx context.Context, entry *entity.LexiconEntry) error {
+	query := `
+		INSERT INTO lexicon (word, provider, version, phonetic, meaning, example, frequency, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		ON CONFLICT (word, provider) DO UPDATE
+		SET version = EXCLUDED.version, phonetic = EXCLUDED.phonetic, meaning = EXCLUDED.meaning,
+			example = EXCLUDED.example, frequency = EXCLUDED.frequency,
+			expires_at = EXCLUDED.expires_at, updated_at = EXCLUDED.updated_at
+		RETURNING id
+	`
+	meaningJSON, _ := json.Marshal(entry.Meaning)
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	err := r.data.db.QueryRowContext(ctx, query,
+		entry.Word, entry.Provider, entry.Version, entry.Phonetic, meaningJSON,
+		entry.Example, entry.Frequency, entry.ExpiresAt, now,
+	).Scan(&entry.ID)
+	if err != nil {
+		r.log.Errorf("failed to put lexicon entry: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Invalidate 删除缓存，word/provider 为空表示不限
+func (r *lexiconRepo) Invalidate(ctx context.Context, word, provider string) (int, error) {
+	query := `DELETE FROM lexicon WHERE ($1 = '' OR word = $1) AND ($2 = '' OR provider = $2)`
+	res, err := r.data.db.ExecContext(ctx, query, word, provider)
+	if err != nil {
+		r.log.Errorf("failed to invalidate lexicon: %v", err)
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
@@ -0,0 +1,76 @@
+// internal/data/stats.go
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// statsStatuses 维护计数的单词状态，对应词典表的 <status>_words 列
+var statsStatuses = []string{"new", "learning", "review", "mastered", "suspended"}
+
+// learnedStatuses 计入 learned_words 的状态
+var learnedStatuses = map[string]bool{"learning": true, "review": true, "mastered": true}
+
+// statsDelta 一次写操作引起的词典计数变化：dict_id -> 状态 -> 增量
+type statsDelta map[int64]map[string]int
+
+func (d statsDelta) add(dictID int64, status string, n int) {
+	if !isStatsStatus(status) {
+		status = "new" // 未知状态按新词计，与 RecomputeStats 一致
+	}
+	if d[dictID] == nil {
+		d[dictID] = make(map[string]int)
+	}
+	d[dictID][status] += n
+}
+
+// move 记录单词从 (fromDict, fromStatus) 变为 (toDict, toStatus)
+func (d statsDelta) move(fromDict int64, fromStatus string, toDict int64, toStatus string) {
+	d.add(fromDict, fromStatus, -1)
+	d.add(toDict, toStatus, 1)
+}
+
+// apply 在事务内更新词典计数，按 dict_id 升序加锁以避免死锁
+func (d statsDelta) apply(ctx context.Context, tx *sql.Tx) error {
+	ids := make([]int64, 0, len(d))
+	for id := range d {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		var set string
+		total, learned := 0, 0
+		for _, status := range statsStatuses {
+			n := d[id][status]
+			if n == 0 {
+				continue
+			}
+			set += fmt.Sprintf("%[1]s_words = %[1]s_words + %[2]d, ", status, n)
+			total += n
+			if learnedStatuses[status] {
+				learned += n
+			}
+		}
+		if set == "" {
+			continue
+		}
+		query := `UPDATE dictionaries SET ` + set + `total_words = total_words + $1, learned_words = learned_words + $2 WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, query, total, learned, id); err != nil {
+			return fmt.Errorf("failed to update dictionary stats: %w", err)
+		}
+	}
+	return nil
+}
+
+func isStatsStatus(status string) bool {
+	for _, s := range statsStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// internal/data/tag.go
+package data
+
+import (
+	"context"
+
+	"backend/internal/biz/entity"
+	"backend/internal/biz/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/lib/pq"
+)
+
+type tagRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewTagRepo 创建标签仓库实例
+func NewTagRepo(data *Data, logger log.Logger) repo.TagRepo {
+	return &tagRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// Ensure 获取用户的标签，不存在的自动创建
+func (r *tagRepo) Ensure(ctx context.Context, userID int64, names []string) ([]*entity.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	// DO UPDATE 使已存在的行也出现在 RETURNING 中
+	query := `
+		INSERT INTO tags (user_id, name)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, user_id, name, created_at
+	`
+	tags, err := r.queryTags(ctx, query, userID, pq.Array(names))
+	if err != nil {
+		r.log.Errorf("failed to ensure tags: %v", err)
+	}
+	return tags, err
+}
+
+// ListByUserID 获取用户的全部标签及其单词数
+func (r *tagRepo) ListByUserID(ctx context.Context, userID int64) ([]*entity.Tag, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name, t.created_at, COUNT(wt.word_id)
+		FROM tags t
+		LEFT JOIN word_tags wt ON wt.tag_id = t.id
+		WHERE t.user_id = $1
+		GROUP BY t.id
+		ORDER BY t.name
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.log.Errorf("failed to list tags: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*entity.Tag
+	for rows.Next() {
+		tag := &entity.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt, &tag.WordCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// ListByNames 按名称获取用户已有的标签
+func (r *tagRepo) ListByNames(ctx context.Context, userID int64, names []string) ([]*entity.Tag, error) {
+	query := `
+		SELECT id, user_id, name, created_at
+		FROM tags
+		WHERE user_id = $1 AND name = ANY($2)
+	`
+	return r.queryTags(ctx, query, userID, pq.Array(names))
+}
+
+func (r *tagRepo) queryTags(ctx context.Context, query string, args ...interface{}) ([]*entity.Tag, error) {
+	rows, err := r.data.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*entity.Tag
+	for rows.Next() {
+		tag := &entity.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// Delete 删除标签
+func (r *tagRepo) Delete(ctx context.Context, userID, tagID int64) (bool, error) {
+	res, err := r.data.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1 AND user_id = $2`, tagID, userID)
+	if err != nil {
+		r.log.Errorf("failed to delete tag: %v", err)
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// AddWords 为单词打上标签
+func (r *tagRepo) AddWords(ctx context.Context, tagIDs, wordIDs []int64) (int, error) {
+	query := `
+		INSERT INTO word_tags (word_id, tag_id)
+		SELECT w, t FROM unnest($1::bigint[]) AS w CROSS JOIN unnest($2::bigint[]) AS t
+		ON CONFLICT DO NOTHING
+	`
+	res, err := r.data.db.ExecContext(ctx, query, pq.Array(wordIDs), pq.Array(tagIDs))
+	if err != nil {
+		r.log.Errorf("failed to tag words: %v", err)
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// RemoveWords 移除单词上的标签
+func (r *tagRepo) RemoveWords(ctx context.Context, tagIDs, wordIDs []int64) (int, error) {
+	query := `DELETE FROM word_tags WHERE word_id = ANY($1) AND tag_id = ANY($2)`
+	res, err := r.data.db.ExecContext(ctx, query, pq.Array(wordIDs), pq.Array(tagIDs))
+	if err != nil {
+		r.log.Errorf("failed to untag words: %v", err)
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// ListNamesByWordIDs 批量获取单词的标签名
+func (r *tagRepo) ListNamesByWordIDs(ctx context.Context, wordIDs []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(wordIDs))
+	if len(wordIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT wt.word_id, t.name
+		FROM word_tags wt
+		INNER JOIN tags t ON t.id = wt.tag_id
+		WHERE wt.word_id = ANY($1)
+		ORDER BY t.name
+	`
+	rows, err := r.data.db.QueryContext(ctx, query, pq.Array(wordIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int64
+		var name string
+		if err := rows.Scan(&wordID, &name); err != nil {
+			return nil, err
+		}
+		result[wordID] = append(result[wordID], name)
+	}
+	return result, rows.Err()
+}
@@ -152,3 +152,57 @@ func (r *uploadTaskRepo) AddFailedWordWithReason(ctx context.Context, id, word,
 	}
 	return nil
 }
+
+// TransitionStatus 仅当任务处于 from 状态时改为 to，避免覆盖并发的取消
+func (r *uploadTaskRepo) TransitionStatus(ctx context.Context, id, from, to string) (bool, error) {
+	query := `
+		UPDATE upload_tasks
+		SET status = $1, updated_at = $2
+		WHERE id = $3 AND status = $4
+	`
+	res, err := r.data.db.ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		r.log.Errorf("failed to transition upload task status: %v", err)
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// UpdateIfStatus 仅当任务处于 from 状态时更新任务
+func (r *uploadTaskRepo) UpdateIfStatus(ctx context.Context, task *entity.UploadTask, from string) (bool, error) {
+	query := `
+		UPDATE upload_tasks
+		SET status = $1, processed_words = $2, failed_words = $3, failed_details = $4, updated_at = $5, completed_at = $6
+		WHERE id = $7 AND status = $8
+	`
+	failedJSON, _ := json.Marshal(task.FailedWords)
+	failedDetailsJSON, _ := json.Marshal(task.FailedDetails)
+	task.UpdatedAt = time.Now()
+
+	res, err := r.data.db.ExecContext(ctx, query,
+		task.Status, task.ProcessedWords, failedJSON, failedDetailsJSON,
+		task.UpdatedAt, task.CompletedAt, task.ID, from,
+	)
+	if err != nil {
+		r.log.Errorf("failed to update upload task: %v", err)
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// CancelUnfinishedByDictID 取消词典下未完成的任务
+func (r *uploadTaskRepo) CancelUnfinishedByDictID(ctx context.Context, dictID int64) error {
+	query := `
+		UPDATE upload_tasks
+		SET status = 'cancelled', updated_at = $1, completed_at = $1
+		WHERE dict_id = $2 AND status IN ('pending', 'processing', 'waiting_provider')
+	`
+	_, err := r.data.db.ExecContext(ctx, query, time.Now(), dictID)
+	if err != nil {
+		r.log.Errorf("failed to cancel upload tasks: %v", err)
+		return err
+	}
+	return nil
+}
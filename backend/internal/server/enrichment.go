@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// EnrichmentWorker 后台补全任务，按配置的间隔为缺少字段或提供方版本过期的单词重新查询翻译链。
+// 实现 kratos transport.Server，随应用启动与停止。
+type EnrichmentWorker struct {
+	uc     *biz.DictionaryUseCase
+	policy *biz.EnrichmentPolicy
+	log    *log.Helper
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewEnrichmentWorker 创建后台补全任务
+func NewEnrichmentWorker(uc *biz.DictionaryUseCase, policy *biz.EnrichmentPolicy, logger log.Logger) *EnrichmentWorker {
+	return &EnrichmentWorker{
+		uc:     uc,
+		policy: policy,
+		log:    log.NewHelper(logger),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start 启动后立即执行一轮，之后按间隔执行，直到 Stop；未启用时只等待停止
+func (w *EnrichmentWorker) Start(ctx context.Context) error {
+	defer close(w.done)
+	if !w.policy.Enabled {
+		<-w.stop
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(w.policy.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.uc.RunEnrichment(runCtx); err != nil && runCtx.Err() == nil {
+			w.log.Warnf("enrichment run stopped: %v", err)
+		}
+		select {
+		case <-runCtx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop 取消正在进行的补全并等待退出
+func (w *EnrichmentWorker) Stop(ctx context.Context) error {
+	close(w.stop)
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+	}
+	return nil
+}
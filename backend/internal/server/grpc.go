@@ -1,22 +1,62 @@
 package server
 
 import (
+	"context"
+	"strings"
+
 	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
 	"backend/internal/conf"
 	"backend/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// authStream 携带已认证用户的 ServerStream
+type authStream struct {
+	ggrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// authStreamInterceptor 为流式 RPC 解析 Bearer token
+// （Kratos 中间件对流式 RPC 不会替换 context，因此使用 gRPC 拦截器）
+func authStreamInterceptor(authSvc *service.AuthService) ggrpc.StreamServerInterceptor {
+	return func(srv interface{}, ss ggrpc.ServerStream, _ *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		authz := ""
+		if values := md.Get("authorization"); len(values) > 0 {
+			authz = strings.TrimSpace(values[0])
+		}
+		if !strings.HasPrefix(authz, "Bearer ") {
+			return handler(srv, ss)
+		}
+
+		token := strings.TrimSpace(strings.TrimPrefix(authz, "Bearer "))
+		userID, err := authSvc.ParseAccessToken(token)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: authctx.WithUserID(ss.Context(), userID)})
+	}
+}
+
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, dictSvc *service.DictionaryService, authSvc *service.AuthService, adminSvc *service.AdminService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
 			requestLogMiddleware(logger),
+			authContextMiddleware(authSvc),
 		),
+		grpc.StreamInterceptor(authStreamInterceptor(authSvc)),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -29,5 +69,7 @@ func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, logger log.L
 	}
 	srv := grpc.NewServer(opts...)
 	v1.RegisterGreeterServer(srv, greeter)
+	v1.RegisterDictionaryServer(srv, dictSvc)
+	v1.RegisterAdminServer(srv, adminSvc)
 	return srv
 }
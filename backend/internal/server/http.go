@@ -44,6 +44,15 @@ func corsFilter() http.FilterFunc {
 	}
 }
 
+// clientContextFilter 保存未加服务端超时的请求 context，流式导出与大文件上传据此不受 http.Timeout 限制
+func clientContextFilter() http.FilterFunc {
+	return func(h stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			h.ServeHTTP(w, r.WithContext(authctx.WithClientContext(r.Context())))
+		})
+	}
+}
+
 func authContextMiddleware(authSvc *service.AuthService) middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
@@ -69,7 +78,7 @@ func authContextMiddleware(authSvc *service.AuthService) middleware.Middleware {
 }
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, dictSvc *service.DictionaryService, learnSvc *service.LearningService, authSvc *service.AuthService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, dictSvc *service.DictionaryService, learnSvc *service.LearningService, authSvc *service.AuthService, adminSvc *service.AdminService, logger log.Logger) *http.Server {
 	_ = logger
 
 	var opts = []http.ServerOption{
@@ -77,7 +86,7 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, dictSvc *ser
 			recovery.Recovery(),
 			authContextMiddleware(authSvc),
 		),
-		http.Filter(corsFilter()),
+		http.Filter(corsFilter(), clientContextFilter()),
 	}
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -94,6 +103,12 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, dictSvc *ser
 	v1.RegisterAuthHTTPServer(srv, authSvc)
 	v1.RegisterDictionaryHTTPServer(srv, dictSvc)
 	v1.RegisterLearningHTTPServer(srv, learnSvc)
+	v1.RegisterAdminHTTPServer(srv, adminSvc)
+
+	// 流式下载、multipart 上传等无法用 proto 描述的接口
+	r := srv.Route("/")
+	r.GET("/api/v1/dictionaries/{id}/export", dictSvc.ExportDictionary)
+	r.POST("/api/v1/dictionaries/upload/file", dictSvc.UploadDictionaryFile)
 
 	return srv
 }
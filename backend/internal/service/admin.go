@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+type AdminService struct {
+	v1.UnimplementedAdminServer
+
+	uc *biz.AdminUseCase
+}
+
+func NewAdminService(uc *biz.AdminUseCase) *AdminService {
+	return &AdminService{uc: uc}
+}
+
+func (s *AdminService) InvalidateLexicon(ctx context.Context, req *v1.InvalidateLexiconRequest) (*v1.InvalidateLexiconReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	n, err := s.uc.InvalidateLexicon(ctx, userID, req.Word, req.Provider, req.All)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.InvalidateLexiconReply{Deleted: int32(n)}, nil
+}
@@ -3,14 +3,48 @@ package service
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+	"strconv"
 
 	v1 "backend/api/helloworld/v1"
 	authctx "backend/internal/auth"
 	"backend/internal/biz"
+	"backend/internal/biz/entity"
+	"backend/pkg/translator"
 
 	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
 )
 
+// OperationDictionaryExportDictionary 导出接口的操作名，用于中间件匹配与日志
+const OperationDictionaryExportDictionary = "/helloworld.v1.Dictionary/ExportDictionary"
+
+// exportStatusTrailer 导出完成后以 HTTP trailer 返回的状态，值为 complete
+const exportStatusTrailer = "X-Export-Status"
+
+// errExportTruncated 导出在响应体写出后失败
+var errExportTruncated = errors.New("export truncated")
+
+// exportWriter 记录是否已向客户端写出数据，用于区分能否返回错误码
+type exportWriter struct {
+	stdhttp.ResponseWriter
+	written bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush 透传给底层的 http.Flusher
+func (w *exportWriter) Flush() {
+	if f, ok := w.ResponseWriter.(stdhttp.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // DictionaryService 词典服务
 type DictionaryService struct {
 	v1.UnimplementedDictionaryServer
@@ -58,37 +92,118 @@ func (s *DictionaryService) ListDictionaries(ctx context.Context, _ *v1.ListDict
 		return nil, err
 	}
 
+	return &v1.ListDictionariesReply{Items: toDictionaryItems(dicts)}, nil
+}
+
+// GetDictionary 获取词典详情
+func (s *DictionaryService) GetDictionary(ctx context.Context, req *v1.GetDictionaryRequest) (*v1.DictionaryItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dict, err := s.uc.GetDictionaryForUser(ctx, req.Id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toDictionaryItem(dict), nil
+}
+
+// UpdateDictionary 修改词典名称与描述
+func (s *DictionaryService) UpdateDictionary(ctx context.Context, req *v1.UpdateDictionaryRequest) (*v1.DictionaryItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dict, err := s.uc.UpdateDictionary(ctx, userID, req.Id, req.Name, req.Description, req.IsPublic)
+	if err != nil {
+		return nil, err
+	}
+	return toDictionaryItem(dict), nil
+}
+
+// DeleteDictionary 删除词典（移入回收站）
+func (s *DictionaryService) DeleteDictionary(ctx context.Context, req *v1.DeleteDictionaryRequest) (*v1.DeleteDictionaryReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	if err := s.uc.DeleteDictionary(ctx, userID, req.Id); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteDictionaryReply{Success: true}, nil
+}
+
+// toDictionaryItem 将词典实体转换为接口返回结构
+func toDictionaryItem(dict *entity.Dictionary) *v1.DictionaryItem {
+	item := &v1.DictionaryItem{
+		Id:           dict.ID,
+		Name:         dict.Name,
+		Description:  dict.Description,
+		TotalWords:   int32(dict.TotalWords),
+		LearnedWords: int32(dict.LearnedWords),
+		Progress:     dict.Progress(),
+		CreatedAt:    dict.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		IsPublic:     dict.IsPublic,
+		Stats: &v1.DictionaryStats{
+			New:       int32(dict.Stats.New),
+			Learning:  int32(dict.Stats.Learning),
+			Review:    int32(dict.Stats.Review),
+			Mastered:  int32(dict.Stats.Mastered),
+			Suspended: int32(dict.Stats.Suspended),
+		},
+	}
+	if dict.DeletedAt != nil {
+		item.DeletedAt = dict.DeletedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return item
+}
+
+func toDictionaryItems(dicts []*entity.Dictionary) []*v1.DictionaryItem {
 	items := make([]*v1.DictionaryItem, 0, len(dicts))
 	for _, dict := range dicts {
-		items = append(items, &v1.DictionaryItem{
-			Id:           dict.ID,
-			Name:         dict.Name,
-			Description:  dict.Description,
-			TotalWords:   int32(dict.TotalWords),
-			LearnedWords: int32(dict.LearnedWords),
-			Progress:     dict.Progress(),
-			CreatedAt:    dict.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		})
+		items = append(items, toDictionaryItem(dict))
 	}
-
-	return &v1.ListDictionariesReply{Items: items}, nil
+	return items
 }
 
-// UploadDictionary 上传词典文件
+// UploadDictionary 上传词典文件（文件内容随 JSON 请求体一次性提交，大文件请使用 multipart 或 gRPC 流式上传）
 func (s *DictionaryService) UploadDictionary(ctx context.Context, req *v1.UploadDictionaryRequest) (*v1.UploadDictionaryReply, error) {
 	userID, ok := authctx.UserIDFromContext(ctx)
 	if !ok || userID <= 0 {
 		return nil, biz.ErrUnauthorized
 	}
+	return s.upload(ctx, userID, bytes.NewReader(req.FileContent), &uploadOptions{
+		name:        req.Name,
+		description: req.Description,
+		dictID:      req.DictId,
+		exclude:     req.ExcludeWords,
+	})
+}
+
+// PreviewUpload 预览上传结果，不写入任何数据
+func (s *DictionaryService) PreviewUpload(ctx context.Context, req *v1.PreviewUploadRequest) (*v1.PreviewUploadReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	return s.preview(ctx, userID, bytes.NewReader(req.FileContent), req.DictId)
+}
+
+// ExtractVocabulary 从文章中提取生词并创建词典
+func (s *DictionaryService) ExtractVocabulary(ctx context.Context, req *v1.ExtractVocabularyRequest) (*v1.UploadDictionaryReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
 
 	name := req.Name
 	if name == "" {
-		name = "未命名词典"
+		name = "文章生词"
 	}
 
-	result, err := s.uc.UploadDictionary(ctx, bytes.NewReader(req.FileContent), name, req.Description, userID)
+	result, err := s.uc.ExtractVocabulary(ctx, req.Text, name, req.Description, int(req.MaxWords), userID)
 	if err != nil {
-		s.log.Warnf("upload dictionary failed, user_id=%d name=%q: %v", userID, name, err)
+		s.log.Warnf("extract vocabulary failed, user_id=%d name=%q: %v", userID, name, err)
 		return nil, err
 	}
 
@@ -110,13 +225,180 @@ func (s *DictionaryService) GetUploadStatus(ctx context.Context, req *v1.GetUplo
 	if err != nil {
 		return nil, err
 	}
+	return s.toUploadStatusReply(task), nil
+}
 
-	return &v1.GetUploadStatusReply{
-		TaskId:      task.ID,
-		Status:      task.Status,
-		Progress:    task.Progress(),
-		Total:       int32(task.TotalWords),
-		Processed:   int32(task.ProcessedWords),
-		FailedWords: task.FailedWords,
-	}, nil
+// RetryUploadTask 重新处理上传任务中失败的单词
+func (s *DictionaryService) RetryUploadTask(ctx context.Context, req *v1.RetryUploadTaskRequest) (*v1.GetUploadStatusReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	task, err := s.uc.RetryUploadTask(ctx, userID, &biz.RetryUploadInput{
+		TaskID:         req.TaskId,
+		Stages:         req.Stages,
+		ManualMeanings: req.ManualMeanings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.toUploadStatusReply(task), nil
+}
+
+func (s *DictionaryService) toUploadStatusReply(task *entity.UploadTask) *v1.GetUploadStatusReply {
+	reply := &v1.GetUploadStatusReply{
+		TaskId:        task.ID,
+		Status:        task.Status,
+		Progress:      task.Progress(),
+		Total:         int32(task.TotalWords),
+		Processed:     int32(task.ProcessedWords),
+		FailedWords:   task.FailedWords,
+		FailedDetails: make([]*v1.FailedDetail, 0, len(task.FailedDetails)),
+	}
+	for _, d := range task.FailedDetails {
+		reply.FailedDetails = append(reply.FailedDetails, &v1.FailedDetail{
+			Word:   d.Word,
+			Stage:  d.Stage,
+			Reason: d.Reason,
+			At:     d.At.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+	}
+	if task.Status == "waiting_provider" {
+		reply.Providers = toProviderHealth(s.uc.ProviderHealth())
+	}
+	return reply
+}
+
+// GetProviderHealth 获取翻译提供方健康状态
+func (s *DictionaryService) GetProviderHealth(ctx context.Context, req *v1.GetProviderHealthRequest) (*v1.GetProviderHealthReply, error) {
+	providers := toProviderHealth(s.uc.ProviderHealth())
+	reply := &v1.GetProviderHealthReply{Providers: providers}
+	for _, p := range providers {
+		if p.State != translator.BreakerOpen {
+			reply.Available = true
+		}
+	}
+	return reply, nil
+}
+
+func toProviderHealth(health []translator.ProviderHealth) []*v1.ProviderHealth {
+	items := make([]*v1.ProviderHealth, 0, len(health))
+	for _, h := range health {
+		item := &v1.ProviderHealth{
+			Name:                h.Name,
+			State:               h.State,
+			ConsecutiveFailures: int32(h.ConsecutiveFailures),
+			LastError:           h.LastError,
+		}
+		if !h.LastFailureAt.IsZero() {
+			item.LastFailureAt = h.LastFailureAt.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		if !h.RetryAt.IsZero() {
+			item.RetryAt = h.RetryAt.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// GetEnrichmentProgress 获取词典的后台补全进度
+func (s *DictionaryService) GetEnrichmentProgress(ctx context.Context, req *v1.GetEnrichmentProgressRequest) (*v1.EnrichmentProgress, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	progress, err := s.uc.GetEnrichmentProgress(ctx, userID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toEnrichmentProgress(progress), nil
+}
+
+// EnrichDictionary 立即在后台补全词典
+func (s *DictionaryService) EnrichDictionary(ctx context.Context, req *v1.EnrichDictionaryRequest) (*v1.EnrichmentProgress, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	progress, err := s.uc.EnrichDictionary(ctx, userID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toEnrichmentProgress(progress), nil
+}
+
+func toEnrichmentProgress(p *biz.EnrichmentProgress) *v1.EnrichmentProgress {
+	item := &v1.EnrichmentProgress{
+		DictId:            p.DictID,
+		TotalWords:        int32(p.Stats.TotalWords),
+		MissingWords:      int32(p.Stats.MissingWords),
+		FailedLookupWords: int32(p.Stats.FailedLookupWords),
+		StaleWords:        int32(p.Stats.StaleWords),
+		Running:           p.Run.Running,
+		Processed:         int32(p.Run.Processed),
+		Enriched:          int32(p.Run.Enriched),
+		Unchanged:         int32(p.Run.Unchanged),
+		Failed:            int32(p.Run.Failed),
+		LastError:         p.Run.LastError,
+	}
+	if !p.Run.StartedAt.IsZero() {
+		item.StartedAt = p.Run.StartedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if !p.Run.FinishedAt.IsZero() {
+		item.FinishedAt = p.Run.FinishedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return item
+}
+
+// ExportDictionary 导出词典（HTTP 流式下载）
+// GET /api/v1/dictionaries/{id}/export?format=jsonl|csv&include_records=true
+func (s *DictionaryService) ExportDictionary(ctx khttp.Context) error {
+	khttp.SetOperation(ctx, OperationDictionaryExportDictionary)
+
+	dictID, err := strconv.ParseInt(ctx.Vars().Get("id"), 10, 64)
+	if err != nil || dictID <= 0 {
+		return biz.ErrInvalidInput
+	}
+	format, err := biz.ParseExportFormat(ctx.Query().Get("format"))
+	if err != nil {
+		return err
+	}
+	includeRecords, _ := strconv.ParseBool(ctx.Query().Get("include_records"))
+
+	h := ctx.Middleware(func(c context.Context, _ interface{}) (interface{}, error) {
+		userID, ok := authctx.UserIDFromContext(c)
+		if !ok || userID <= 0 {
+			return nil, biz.ErrUnauthorized
+		}
+		dict, err := s.uc.GetDictionaryForUser(c, dictID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// 导出耗时随词典大小增长，不受服务端超时限制，客户端断开时才中止
+		exportCtx, cancel := authctx.WithoutServerTimeout(c)
+		defer cancel()
+
+		w := &exportWriter{ResponseWriter: ctx.Response()}
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="dictionary_%d.%s"`, dict.ID, format))
+		w.Header().Set("Trailer", exportStatusTrailer)
+		if err := s.uc.ExportDictionary(exportCtx, dict, format, includeRecords, w); err != nil {
+			if !w.written {
+				w.Header().Del("Content-Disposition")
+				w.Header().Del("Trailer")
+				return nil, err
+			}
+			s.log.WithContext(c).Errorf("export dictionary failed, dict_id=%d format=%s: %v", dict.ID, format, err)
+			return nil, errExportTruncated
+		}
+		w.Header().Set(exportStatusTrailer, "complete")
+		return nil, nil
+	})
+	_, err = h(ctx, nil)
+	if errors.Is(err, errExportTruncated) {
+		// 响应体已部分写出，中断连接让客户端感知下载不完整，而不是收到截断的 200
+		panic(stdhttp.ErrAbortHandler)
+	}
+	return err
 }
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 
 	v1 "backend/api/helloworld/v1"
 	authctx "backend/internal/auth"
@@ -39,28 +38,14 @@ func (s *LearningService) GetTodayTasks(ctx context.Context, req *v1.GetTodayTas
 		limit = 20
 	}
 
-	result, err := s.uc.GetTodayTasks(ctx, userID, req.DictId, limit)
+	result, err := s.uc.GetTodayTasks(ctx, userID, req.DictId, biz.NormalizeTag(req.Tag), limit)
 	if err != nil {
 		return nil, err
 	}
 
 	words := make([]*v1.WordItem, 0, len(result.Words))
 	for _, w := range result.Words {
-		meaningJSON, _ := json.Marshal(w.Meaning)
-		nextReview := ""
-		if w.NextReviewDate != nil {
-			nextReview = w.NextReviewDate.Format("2006-01-02")
-		}
-		words = append(words, &v1.WordItem{
-			Id:             w.ID,
-			Word:           w.Word,
-			Phonetic:       w.Phonetic,
-			Meaning:        meaningJSON,
-			Example:        w.Example,
-			AudioUrl:       w.AudioURL,
-			Status:         w.Status,
-			NextReviewDate: nextReview,
-		})
+		words = append(words, toWordItem(w))
 	}
 
 	return &v1.GetTodayTasksReply{
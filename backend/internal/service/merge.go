@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+// MergeDictionaries 合并词典
+func (s *DictionaryService) MergeDictionaries(ctx context.Context, req *v1.MergeDictionariesRequest) (*v1.MergeDictionariesReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.MergeDictionaries(ctx, userID, &biz.MergeInput{
+		SourceDictIDs: req.SourceDictIds,
+		TargetDictID:  req.TargetDictId,
+		Name:          req.Name,
+		Description:   req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.MergeDictionariesReply{
+		Dictionary:     toDictionaryItem(result.Dictionary),
+		Moved:          int32(result.Moved),
+		Deduplicated:   int32(result.Deduplicated),
+		DeletedDictIds: result.DeletedDictIDs,
+	}, nil
+}
+
+// SplitDictionary 拆分词典
+func (s *DictionaryService) SplitDictionary(ctx context.Context, req *v1.SplitDictionaryRequest) (*v1.SplitDictionaryReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	in := &biz.SplitInput{
+		DictID:    req.Id,
+		ChunkSize: int(req.ChunkSize),
+		Name:      req.Name,
+	}
+	if req.Filter != nil {
+		filter, err := toWordFilter(req.Id, req.Filter)
+		if err != nil {
+			return nil, err
+		}
+		in.Filter = filter
+	}
+
+	result, err := s.uc.SplitDictionary(ctx, userID, in)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SplitDictionaryReply{
+		Dictionaries: toDictionaryItems(result.Dictionaries),
+		Moved:        int32(result.Moved),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+// ListPublicDictionaries 浏览公开词典目录，无需登录
+func (s *DictionaryService) ListPublicDictionaries(ctx context.Context, req *v1.ListPublicDictionariesRequest) (*v1.ListPublicDictionariesReply, error) {
+	catalog, err := s.uc.ListPublicDictionaries(ctx, req.Q, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*v1.PublicDictionaryItem, 0, len(catalog.Items))
+	for _, dict := range catalog.Items {
+		items = append(items, &v1.PublicDictionaryItem{
+			Id:          dict.ID,
+			Name:        dict.Name,
+			Description: dict.Description,
+			TotalWords:  int32(dict.TotalWords),
+			OwnerName:   dict.OwnerName,
+			CloneCount:  int32(dict.CloneCount),
+			CreatedAt:   dict.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+	return &v1.ListPublicDictionariesReply{Items: items, HasMore: catalog.HasMore}, nil
+}
+
+// CloneDictionary 克隆公开词典到当前账号
+func (s *DictionaryService) CloneDictionary(ctx context.Context, req *v1.CloneDictionaryRequest) (*v1.DictionaryItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dict, err := s.uc.CloneDictionary(ctx, userID, req.Id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toDictionaryItem(dict), nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+// ListTags 获取当前用户的全部标签
+func (s *DictionaryService) ListTags(ctx context.Context, _ *v1.ListTagsRequest) (*v1.ListTagsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	tags, err := s.uc.ListTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.TagItem, 0, len(tags))
+	for _, t := range tags {
+		items = append(items, &v1.TagItem{
+			Id:        t.ID,
+			Name:      t.Name,
+			WordCount: int32(t.WordCount),
+			CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+	return &v1.ListTagsReply{Tags: items}, nil
+}
+
+// TagWords 为单词批量打标签
+func (s *DictionaryService) TagWords(ctx context.Context, req *v1.TagWordsRequest) (*v1.TagWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.TagWords(ctx, userID, req.WordIds, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.TagWordsReply{Affected: int32(result.Affected)}, nil
+}
+
+// UntagWords 批量移除单词上的标签
+func (s *DictionaryService) UntagWords(ctx context.Context, req *v1.TagWordsRequest) (*v1.TagWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.UntagWords(ctx, userID, req.WordIds, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.TagWordsReply{Affected: int32(result.Affected)}, nil
+}
+
+// DeleteTag 删除标签
+func (s *DictionaryService) DeleteTag(ctx context.Context, req *v1.DeleteTagRequest) (*v1.DeleteTagReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	if err := s.uc.DeleteTag(ctx, userID, req.Id); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteTagReply{Success: true}, nil
+}
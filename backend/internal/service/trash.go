@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+// ListTrash 获取回收站中的词典
+func (s *DictionaryService) ListTrash(ctx context.Context, _ *v1.ListTrashRequest) (*v1.ListDictionariesReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dicts, err := s.uc.ListTrash(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ListDictionariesReply{Items: toDictionaryItems(dicts)}, nil
+}
+
+// RestoreDictionary 从回收站恢复词典
+func (s *DictionaryService) RestoreDictionary(ctx context.Context, req *v1.RestoreDictionaryRequest) (*v1.DictionaryItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dict, err := s.uc.RestoreDictionary(ctx, userID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toDictionaryItem(dict), nil
+}
+
+// PurgeDictionary 彻底删除回收站中的词典
+func (s *DictionaryService) PurgeDictionary(ctx context.Context, req *v1.PurgeDictionaryRequest) (*v1.DeleteDictionaryReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	if err := s.uc.PurgeDictionary(ctx, userID, req.Id); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteDictionaryReply{Success: true}, nil
+}
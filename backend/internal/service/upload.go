@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// OperationDictionaryUploadDictionaryFile multipart 上传接口的操作名，用于中间件匹配与日志
+const OperationDictionaryUploadDictionaryFile = "/helloworld.v1.Dictionary/UploadDictionaryFile"
+
+// maxFormFieldBytes multipart 普通表单字段的最大长度
+const maxFormFieldBytes = 4 << 10
+
+// uploadOptions 上传参数，dictID 大于 0 时追加到已有词典
+type uploadOptions struct {
+	name        string
+	description string
+	dictID      int64
+	exclude     []string
+	dryRun      bool // 仅预览，不创建任务（multipart 上传）
+}
+
+// upload 解析单词文件并创建上传任务
+func (s *DictionaryService) upload(ctx context.Context, userID int64, reader io.Reader, opts *uploadOptions) (*v1.UploadDictionaryReply, error) {
+	var (
+		result *biz.UploadTaskResult
+		err    error
+	)
+	if opts.dictID > 0 {
+		result, err = s.uc.AppendToDictionary(ctx, reader, opts.dictID, userID, opts.exclude)
+		if err != nil {
+			s.log.Warnf("append to dictionary failed, user_id=%d dict_id=%d: %v", userID, opts.dictID, err)
+			return nil, err
+		}
+	} else {
+		name := opts.name
+		if name == "" {
+			name = "未命名词典"
+		}
+		result, err = s.uc.UploadDictionary(ctx, reader, name, opts.description, userID, opts.exclude)
+		if err != nil {
+			s.log.Warnf("upload dictionary failed, user_id=%d name=%q: %v", userID, name, err)
+			return nil, err
+		}
+	}
+
+	return &v1.UploadDictionaryReply{
+		TaskId:         result.TaskID,
+		Status:         result.Status,
+		TotalWords:     int32(result.TotalWords),
+		ProcessedWords: int32(result.ProcessedWords),
+		ReceivedBytes:  result.ReceivedBytes,
+		InvalidLines:   int32(result.InvalidLines),
+	}, nil
+}
+
+// preview 解析单词文件并返回预览结果
+func (s *DictionaryService) preview(ctx context.Context, userID int64, reader io.Reader, dictID int64) (*v1.PreviewUploadReply, error) {
+	preview, err := s.uc.PreviewUpload(ctx, reader, dictID, userID)
+	if err != nil {
+		return nil, err
+	}
+	words := make([]*v1.UploadPreviewWord, 0, len(preview.Words))
+	for _, w := range preview.Words {
+		words = append(words, &v1.UploadPreviewWord{Word: w.Word, Lemma: w.Lemma, Action: w.Action})
+	}
+	return &v1.PreviewUploadReply{
+		ValidWords:       int32(len(preview.Words)),
+		InvalidLines:     int32(preview.InvalidLines),
+		Duplicates:       preview.Duplicates,
+		ExistingCount:    int32(preview.ExistingCount),
+		ReusableCount:    int32(preview.ReusableCount),
+		ProvidedCount:    int32(preview.ProvidedCount),
+		CachedCount:      int32(preview.CachedCount),
+		TranslateCount:   int32(preview.TranslateCount),
+		EstimatedSeconds: int32(math.Ceil(preview.EstimatedDuration.Seconds())),
+		Words:            words,
+		ReceivedBytes:    preview.ReceivedBytes,
+	}, nil
+}
+
+// UploadDictionaryFile 以 multipart/form-data 流式上传词典文件（HTTP）
+// POST /api/v1/dictionaries/upload/file，表单字段 name/description/dict_id/exclude_words/dry_run 需位于 file 之前；
+// dry_run=true 时只返回预览结果
+func (s *DictionaryService) UploadDictionaryFile(ctx khttp.Context) error {
+	khttp.SetOperation(ctx, OperationDictionaryUploadDictionaryFile)
+
+	h := ctx.Middleware(func(c context.Context, _ interface{}) (interface{}, error) {
+		userID, ok := authctx.UserIDFromContext(c)
+		if !ok || userID <= 0 {
+			return nil, biz.ErrUnauthorized
+		}
+		// 大文件在慢速链路上的接收时间可能超过服务端超时，只随客户端断开而中止
+		c, cancel := authctx.WithoutServerTimeout(c)
+		defer cancel()
+
+		mr, err := ctx.Request().MultipartReader()
+		if err != nil {
+			return nil, biz.ErrInvalidUploadForm
+		}
+
+		opts := &uploadOptions{}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, biz.ErrMissingUploadFile
+			}
+			if err != nil {
+				return nil, biz.ErrInvalidUploadForm
+			}
+			if part.FormName() == "file" {
+				// 直接把文件分段交给解析器，边接收边解析
+				if opts.dryRun {
+					return s.preview(c, userID, part, opts.dictID)
+				}
+				return s.upload(c, userID, part, opts)
+			}
+
+			data, err := io.ReadAll(io.LimitReader(part, maxFormFieldBytes))
+			if err != nil {
+				return nil, biz.ErrInvalidUploadForm
+			}
+			value := strings.TrimSpace(string(data))
+			switch part.FormName() {
+			case "name":
+				opts.name = value
+			case "description":
+				opts.description = value
+			case "dict_id":
+				if opts.dictID, err = strconv.ParseInt(value, 10, 64); err != nil {
+					return nil, biz.ErrInvalidUploadForm
+				}
+			case "exclude_words":
+				opts.exclude = append(opts.exclude, value)
+			case "dry_run":
+				opts.dryRun, _ = strconv.ParseBool(value)
+			}
+		}
+	})
+	out, err := h(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return ctx.Result(200, out)
+}
+
+// UploadDictionaryStream 客户端流式上传词典文件（gRPC）
+func (s *DictionaryService) UploadDictionaryStream(stream v1.Dictionary_UploadDictionaryStreamServer) error {
+	ctx := stream.Context()
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return biz.ErrUnauthorized
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return biz.ErrEmptyWordFile
+	}
+	if err != nil {
+		return err
+	}
+	reader := &uploadChunkReader{stream: stream, buf: first.Content}
+	reply, err := s.upload(ctx, userID, reader, &uploadOptions{
+		name:        first.Name,
+		description: first.Description,
+		dictID:      first.DictId,
+		exclude:     first.ExcludeWords,
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+// uploadChunkReader 将 gRPC 上传流适配为 io.Reader，按需接收下一段内容
+type uploadChunkReader struct {
+	stream v1.Dictionary_UploadDictionaryStreamServer
+	buf    []byte
+}
+
+func (r *uploadChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Content
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
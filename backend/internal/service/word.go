@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+	"backend/internal/biz/entity"
+	"backend/pkg/meaning"
+	"backend/pkg/nlp"
+)
+
+// toWordMeaning 将释义转换为接口返回结构
+func toWordMeaning(m meaning.Meaning) *v1.WordMeaning {
+	senses := make([]*v1.WordSense, 0, len(m.Senses))
+	for _, s := range m.Senses {
+		senses = append(senses, &v1.WordSense{
+			Pos:          s.POS,
+			Definitions:  s.Definitions,
+			Translations: s.Translations,
+			Examples:     s.Examples,
+			Synonyms:     s.Synonyms,
+			Antonyms:     s.Antonyms,
+		})
+	}
+	return &v1.WordMeaning{Senses: senses, Tags: m.Tags}
+}
+
+// toWordItem 将单词实体转换为接口返回结构
+func toWordItem(w *entity.Word) *v1.WordItem {
+	nextReview := ""
+	if w.NextReviewDate != nil {
+		nextReview = w.NextReviewDate.Format("2006-01-02")
+	}
+	return &v1.WordItem{
+		Id:             w.ID,
+		Word:           w.Word,
+		Phonetic:       w.Phonetic,
+		Meaning:        toWordMeaning(w.Meaning),
+		Example:        w.Example,
+		AudioUrl:       w.AudioURL,
+		Status:         w.Status,
+		NextReviewDate: nextReview,
+		DictId:         w.DictID,
+		EfFactor:       w.EFFactor,
+		Interval:       int32(w.Interval),
+		Repetitions:    int32(w.Repetitions),
+		Frequency:      int32(w.Frequency),
+		Tags:           w.Tags,
+		Notes:          w.Notes,
+		Mnemonic:       w.Mnemonic,
+		Provider:       w.Provider,
+	}
+}
+
+// ListWords 分页查询词典单词
+func (s *DictionaryService) ListWords(ctx context.Context, req *v1.ListWordsRequest) (*v1.ListWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	sort, err := biz.ParseWordSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := toWordFilter(req.DictId, &v1.WordFilter{
+		Status:          req.Status,
+		MinEf:           req.MinEf,
+		MaxEf:           req.MaxEf,
+		DueBefore:       req.DueBefore,
+		DueAfter:        req.DueAfter,
+		Prefix:          req.Prefix,
+		HasFailedLookup: req.HasFailedLookup,
+		Tag:             req.Tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	filter.Sort = sort
+	filter.Descending = req.Desc
+	filter.Limit = int(req.Limit)
+
+	result, err := s.uc.ListWords(ctx, userID, filter, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	words := make([]*v1.WordItem, 0, len(result.Words))
+	for _, w := range result.Words {
+		words = append(words, toWordItem(w))
+	}
+	counts := make(map[string]int32, len(result.StatusCounts))
+	for status, n := range result.StatusCounts {
+		counts[status] = int32(n)
+	}
+	return &v1.ListWordsReply{
+		Words:        words,
+		NextCursor:   result.NextCursor,
+		StatusCounts: counts,
+		Total:        int32(result.Total),
+	}, nil
+}
+
+// toWordFilter 将接口筛选条件转换为查询条件
+func toWordFilter(dictID int64, f *v1.WordFilter) (*entity.WordFilter, error) {
+	filter := &entity.WordFilter{
+		DictID:          dictID,
+		Statuses:        f.Status,
+		MinEF:           f.MinEf,
+		MaxEF:           f.MaxEf,
+		Prefix:          nlp.Fold(f.Prefix),
+		HasFailedLookup: f.HasFailedLookup,
+		Tag:             biz.NormalizeTag(f.Tag),
+	}
+	var err error
+	if filter.DueBefore, err = parseDate(f.DueBefore); err != nil {
+		return nil, err
+	}
+	if filter.DueAfter, err = parseDate(f.DueAfter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseDate 解析 YYYY-MM-DD 格式的日期，空字符串返回 nil
+func parseDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, biz.ErrInvalidDate
+	}
+	return &t, nil
+}
+
+// AddWord 向词典添加单个单词
+func (s *DictionaryService) AddWord(ctx context.Context, req *v1.AddWordRequest) (*v1.WordItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	word, err := s.uc.AddWord(ctx, userID, &biz.AddWordInput{
+		DictID:   req.DictId,
+		Word:     req.Word,
+		Meaning:  req.Meaning,
+		Phonetic: req.Phonetic,
+		Example:  req.Example,
+		Notes:    req.Notes,
+		Mnemonic: req.Mnemonic,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWordItem(word), nil
+}
+
+// UpdateWord 编辑单词
+func (s *DictionaryService) UpdateWord(ctx context.Context, req *v1.UpdateWordRequest) (*v1.WordItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	word, err := s.uc.UpdateWord(ctx, userID, &biz.UpdateWordInput{
+		ID:        req.Id,
+		Phonetic:  req.Phonetic,
+		Meaning:   req.Meaning,
+		Example:   req.Example,
+		Notes:     req.Notes,
+		Mnemonic:  req.Mnemonic,
+		Suspended: req.Suspended,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWordItem(word), nil
+}
+
+// DeleteWord 删除单词
+func (s *DictionaryService) DeleteWord(ctx context.Context, req *v1.DeleteWordRequest) (*v1.DeleteWordReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	if err := s.uc.DeleteWord(ctx, userID, req.Id); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteWordReply{Success: true}, nil
+}
+
+// MoveWords 移动单词到另一词典
+func (s *DictionaryService) MoveWords(ctx context.Context, req *v1.TransferWordsRequest) (*v1.TransferWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.MoveWords(ctx, userID, req.WordIds, req.TargetDictId)
+	if err != nil {
+		return nil, err
+	}
+	return toTransferWordsReply(result), nil
+}
+
+// CopyWords 复制单词到另一词典
+func (s *DictionaryService) CopyWords(ctx context.Context, req *v1.TransferWordsRequest) (*v1.TransferWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.CopyWords(ctx, userID, req.WordIds, req.TargetDictId)
+	if err != nil {
+		return nil, err
+	}
+	return toTransferWordsReply(result), nil
+}
+
+func toTransferWordsReply(result *biz.TransferResult) *v1.TransferWordsReply {
+	return &v1.TransferWordsReply{
+		Transferred:  int32(result.Transferred),
+		SkippedWords: result.SkippedWords,
+	}
+}
+
+// SearchWords 全文检索单词
+func (s *DictionaryService) SearchWords(ctx context.Context, req *v1.SearchWordsRequest) (*v1.SearchWordsReply, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	result, err := s.uc.SearchWords(ctx, userID, req.Q, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*v1.SearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		highlights := make([]*v1.SearchHighlight, 0, len(hit.Highlights))
+		for _, h := range hit.Highlights {
+			highlights = append(highlights, &v1.SearchHighlight{Field: h.Field, Snippet: h.Snippet})
+		}
+		hits = append(hits, &v1.SearchHit{
+			Word:       toWordItem(hit.Word),
+			DictName:   hit.DictName,
+			Score:      hit.Score,
+			Highlights: highlights,
+		})
+	}
+	return &v1.SearchWordsReply{Hits: hits, HasMore: result.HasMore}, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	v1 "backend/api/helloworld/v1"
+	authctx "backend/internal/auth"
+	"backend/internal/biz"
+)
+
+// ListWordlists 获取内置词表
+func (s *DictionaryService) ListWordlists(ctx context.Context, _ *v1.ListWordlistsRequest) (*v1.ListWordlistsReply, error) {
+	lists, err := s.uc.ListBuiltinWordlists()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*v1.WordlistItem, 0, len(lists))
+	for _, l := range lists {
+		items = append(items, &v1.WordlistItem{
+			Id:          l.ID,
+			Name:        l.Name,
+			Description: l.Description,
+			WordCount:   int32(l.WordCount),
+		})
+	}
+	return &v1.ListWordlistsReply{Items: items}, nil
+}
+
+// CreateFromWordlist 基于内置词表创建词典
+func (s *DictionaryService) CreateFromWordlist(ctx context.Context, req *v1.CreateFromWordlistRequest) (*v1.DictionaryItem, error) {
+	userID, ok := authctx.UserIDFromContext(ctx)
+	if !ok || userID <= 0 {
+		return nil, biz.ErrUnauthorized
+	}
+	dict, err := s.uc.CreateFromWordlist(ctx, userID, req.ListId, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toDictionaryItem(dict), nil
+}
@@ -0,0 +1,181 @@
+// Package meaning 定义单词释义的结构：按词性分组的义项，各含英文释义、中文翻译、例句、同义词与反义词。
+package meaning
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// glossPOS 释义行开头的词性，如 "n. 苹果"、"vt. & vi. 放弃"
+var glossPOS = regexp.MustCompile(`^([a-z]{1,6}\.(?:\s*[&/,]\s*[a-z]{1,6}\.)*)\s*(.*)$`)
+
+// Meaning 单词释义
+type Meaning struct {
+	Senses []Sense  `json:"senses,omitempty"`
+	Tags   []string `json:"tags,omitempty"` // 考试标签，如 CET4、IELTS
+}
+
+// Sense 同一词性下的义项
+type Sense struct {
+	POS          string   `json:"pos,omitempty"`          // 词性，如 "n."、"noun"，未知时为空
+	Definitions  []string `json:"definitions,omitempty"`  // 英文释义
+	Translations []string `json:"translations,omitempty"` // 中文释义
+	Examples     []string `json:"examples,omitempty"`
+	Synonyms     []string `json:"synonyms,omitempty"`
+	Antonyms     []string `json:"antonyms,omitempty"`
+}
+
+// IsEmpty 没有任何释义或翻译
+func (m *Meaning) IsEmpty() bool {
+	for _, s := range m.Senses {
+		if len(s.Definitions) > 0 || len(s.Translations) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Sense 返回指定词性的义项，不存在时按出现顺序追加
+func (m *Meaning) Sense(pos string) *Sense {
+	pos = strings.TrimSpace(pos)
+	for i := range m.Senses {
+		if m.Senses[i].POS == pos {
+			return &m.Senses[i]
+		}
+	}
+	m.Senses = append(m.Senses, Sense{POS: pos})
+	return &m.Senses[len(m.Senses)-1]
+}
+
+// AddDefinition 添加英文释义
+func (m *Meaning) AddDefinition(pos, text string) {
+	if text = strings.TrimSpace(text); text != "" {
+		s := m.Sense(pos)
+		s.Definitions = appendUnique(s.Definitions, text)
+	}
+}
+
+// AddTranslation 添加中文释义
+func (m *Meaning) AddTranslation(pos, text string) {
+	if text = strings.TrimSpace(text); text != "" {
+		s := m.Sense(pos)
+		s.Translations = appendUnique(s.Translations, text)
+	}
+}
+
+// AddGloss 添加语言未知的释义：含中日韩文字时作为翻译，否则作为英文释义
+func (m *Meaning) AddGloss(pos, text string) {
+	if hasHan(text) {
+		m.AddTranslation(pos, text)
+	} else {
+		m.AddDefinition(pos, text)
+	}
+}
+
+// Text 拼接全部释义与翻译，用于检索高亮
+func (m *Meaning) Text() string {
+	var parts []string
+	for _, s := range m.Senses {
+		for _, list := range [][]string{s.Translations, s.Definitions} {
+			for _, text := range list {
+				if s.POS != "" {
+					text = s.POS + " " + text
+				}
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, "；")
+}
+
+// Gloss 一行释义及其词性
+type Gloss struct {
+	POS  string
+	Text string
+}
+
+// SplitGlosses 拆分多行释义文本（如 "n. 苹果\nv. 吃"），识别行首的词性
+func SplitGlosses(text string) []Gloss {
+	var glosses []Gloss
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		g := Gloss{Text: line}
+		if match := glossPOS.FindStringSubmatch(line); match != nil && match[2] != "" {
+			g.POS, g.Text = match[1], match[2]
+		}
+		glosses = append(glosses, g)
+	}
+	return glosses
+}
+
+// ParseGlosses 将多行释义文本按词性分组，中文行作为翻译，其余作为英文释义
+func ParseGlosses(text string) Meaning {
+	var m Meaning
+	for _, g := range SplitGlosses(text) {
+		m.AddGloss(g.POS, g.Text)
+	}
+	return m
+}
+
+// legacyMeaning 旧版释义结构：{"definitions":[{"pos","text"}],"english":[...],"tags":[...]}
+type legacyMeaning struct {
+	Definitions []legacyGloss `json:"definitions"`
+	English     []legacyGloss `json:"english"`
+	Tags        []string      `json:"tags"`
+}
+
+type legacyGloss struct {
+	POS  string `json:"pos"`
+	Text string `json:"text"`
+}
+
+// UnmarshalJSON 兼容迁移前的旧版结构
+func (m *Meaning) UnmarshalJSON(data []byte) error {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	_, hasSenses := probe["senses"]
+	_, hasDefinitions := probe["definitions"]
+	_, hasEnglish := probe["english"]
+	if hasSenses || (!hasDefinitions && !hasEnglish) {
+		type plain Meaning
+		return json.Unmarshal(data, (*plain)(m))
+	}
+
+	var legacy legacyMeaning
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	*m = Meaning{Tags: legacy.Tags}
+	for _, g := range legacy.Definitions {
+		m.AddGloss(g.POS, g.Text)
+	}
+	for _, g := range legacy.English {
+		m.AddDefinition(g.POS, g.Text)
+	}
+	return nil
+}
+
+func hasHan(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
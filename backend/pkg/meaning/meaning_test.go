@@ -0,0 +1,62 @@
+package meaning
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseGlossesGroupsByPartOfSpeech(t *testing.T) {
+	t.Parallel()
+
+	m := ParseGlosses("n. 苹果\nn. 苹果树\nv. to eat apples\n  \nfruit")
+	want := []Sense{
+		{POS: "n.", Translations: []string{"苹果", "苹果树"}},
+		{POS: "v.", Definitions: []string{"to eat apples"}},
+		{Definitions: []string{"fruit"}},
+	}
+	if !reflect.DeepEqual(m.Senses, want) {
+		t.Fatalf("unexpected senses: %+v", m.Senses)
+	}
+	if m.IsEmpty() {
+		t.Fatal("meaning should not be empty")
+	}
+	if got := m.Text(); got != "n. 苹果；n. 苹果树；v. to eat apples；fruit" {
+		t.Fatalf("unexpected text: %q", got)
+	}
+}
+
+func TestUnmarshalLegacyMeaning(t *testing.T) {
+	t.Parallel()
+
+	legacy := `{"definitions":[{"pos":"vt.","text":"放弃"},{"text":"abandonment"}],"english":[{"pos":"v.","text":"forsake"}],"tags":["CET4"]}`
+	var m Meaning
+	if err := json.Unmarshal([]byte(legacy), &m); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	want := Meaning{
+		Senses: []Sense{
+			{POS: "vt.", Translations: []string{"放弃"}},
+			{Definitions: []string{"abandonment"}},
+			{POS: "v.", Definitions: []string{"forsake"}},
+		},
+		Tags: []string{"CET4"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("unexpected meaning: %+v", m)
+	}
+
+	data, _ := json.Marshal(m)
+	var again Meaning
+	if err := json.Unmarshal(data, &again); err != nil || !reflect.DeepEqual(again, want) {
+		t.Fatalf("round trip failed: %s %v", data, err)
+	}
+
+	var empty Meaning
+	if err := json.Unmarshal([]byte(`{}`), &empty); err != nil || !empty.IsEmpty() {
+		t.Fatalf("empty meaning: %+v %v", empty, err)
+	}
+	if data, _ := json.Marshal(empty); string(data) != "{}" {
+		t.Fatalf("empty meaning should marshal to {}, got %s", data)
+	}
+}
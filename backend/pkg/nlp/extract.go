@@ -0,0 +1,154 @@
+// pkg/nlp/extract.go
+package nlp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	// sentenceEndPattern 句末标点（后接空白）或空行视为句子边界
+	sentenceEndPattern = regexp.MustCompile(`([.!?。！？]["'”’)]*)\s+|\n\s*\n`)
+	// tokenPattern 英文单词，允许内部连字符与撇号（well-known、don't）
+	tokenPattern = regexp.MustCompile(`[A-Za-z]+(?:['’-][A-Za-z]+)*`)
+)
+
+// Candidate 从文章中提取出的候选生词
+type Candidate struct {
+	Lemma    string // 词元（原形）
+	Count    int    // 在文章中出现的次数（各种变形合计）
+	Rank     int    // 通用语料中的词频排名，越小越常用，0 表示未知
+	Sentence string // 首次出现时所在的句子，作为例句
+}
+
+// ExtractOptions 提取参数
+type ExtractOptions struct {
+	// MinLength 词元最短长度，过短的单词通常没有学习价值
+	MinLength int
+}
+
+// SplitSentences 将文本切分为句子
+func SplitSentences(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var sentences []string
+	last := 0
+	for _, loc := range sentenceEndPattern.FindAllStringSubmatchIndex(text, -1) {
+		end := loc[1]
+		if loc[2] >= 0 {
+			// 保留句末标点，丢弃其后的空白
+			end = loc[3]
+		}
+		if s := normalizeSpace(text[last:end]); s != "" {
+			sentences = append(sentences, s)
+		}
+		last = loc[1]
+	}
+	if s := normalizeSpace(text[last:]); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// Tokenize 提取句子中的英文单词（保留原始大小写）
+func Tokenize(sentence string) []string {
+	return tokenPattern.FindAllString(sentence, -1)
+}
+
+// ExtractVocabulary 从英文文章中提取值得学习的单词
+// 流程：分句 -> 分词 -> 词形还原 -> 剔除停用词与专有名词 -> 按出现次数降序排列（次数相同时按首次出现的先后）。
+// 结果不含语料词频，调用方填入 Rank 后用 RankByFrequency 重新排序
+func ExtractVocabulary(text string, opts ExtractOptions) []*Candidate {
+	if opts.MinLength <= 0 {
+		opts.MinLength = 3
+	}
+
+	byLemma := make(map[string]*Candidate)
+	// 句中以大写开头、且从未以小写形式出现的单词视为专有名词；句首单词大小写无法判断，不参与统计
+	properSeen := make(map[string]bool)
+	lowerSeen := make(map[string]bool)
+	var order []string
+
+	for _, sentence := range SplitSentences(text) {
+		for i, token := range Tokenize(sentence) {
+			word := stripPossessive(token)
+			if strings.ContainsAny(word, "'’") || isAcronym(word) {
+				// don't、I'm 等缩写以及 NASA 等首字母缩略词不作为生词
+				continue
+			}
+			lemma := Lemmatize(word)
+			if len(lemma) < opts.MinLength || IsStopword(lemma) {
+				continue
+			}
+			switch {
+			case !startsUpper(word):
+				lowerSeen[lemma] = true
+			case i > 0:
+				properSeen[lemma] = true
+			}
+
+			c, ok := byLemma[lemma]
+			if !ok {
+				c = &Candidate{Lemma: lemma, Sentence: sentence}
+				byLemma[lemma] = c
+				order = append(order, lemma)
+			}
+			c.Count++
+		}
+	}
+
+	result := make([]*Candidate, 0, len(order))
+	for _, lemma := range order {
+		if properSeen[lemma] && !lowerSeen[lemma] {
+			continue
+		}
+		result = append(result, byLemma[lemma])
+	}
+
+	// result 已按首次出现排列，稳定排序保证次数相同时保持原文顺序
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+	return result
+}
+
+// RankByFrequency 按通用语料词频排序：有排名的单词按排名升序（越常用越靠前），
+// 没有排名的排在最后；排名相同时按文章内出现次数降序，再按原有顺序
+func RankByFrequency(candidates []*Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Rank > 0) != (b.Rank > 0) {
+			return a.Rank > 0
+		}
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		return a.Count > b.Count
+	})
+}
+
+// stripPossessive 去掉所有格后缀：teacher's -> teacher
+func stripPossessive(token string) string {
+	for _, suffix := range []string{"'s", "’s"} {
+		if strings.HasSuffix(token, suffix) {
+			return strings.TrimSuffix(token, suffix)
+		}
+	}
+	return token
+}
+
+func startsUpper(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func isAcronym(word string) bool {
+	return len(word) > 1 && strings.ToUpper(word) == word
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,239 @@
+// pkg/nlp/lemmatizer.go
+package nlp
+
+import "strings"
+
+// irregularLemmas 不规则变形表（变形 -> 原形）
+var irregularLemmas = map[string]string{
+	// be / have / do
+	"am":     "be",
+	"is":     "be",
+	"are":    "be",
+	"was":    "be",
+	"were":   "be",
+	"been":   "be",
+	"being":  "be",
+	"has":    "have",
+	"had":    "have",
+	"having": "have",
+	"does":   "do",
+	"did":    "do",
+	"done":   "do",
+	"doing":  "do",
+	// 常见不规则动词
+	"ate":        "eat",
+	"eaten":      "eat",
+	"began":      "begin",
+	"begun":      "begin",
+	"bought":     "buy",
+	"brought":    "bring",
+	"built":      "build",
+	"came":       "come",
+	"caught":     "catch",
+	"chose":      "choose",
+	"chosen":     "choose",
+	"drank":      "drink",
+	"drunk":      "drink",
+	"drove":      "drive",
+	"driven":     "drive",
+	"fallen":     "fall",
+	"felt":       "feel",
+	"fought":     "fight",
+	"flew":       "fly",
+	"flown":      "fly",
+	"forgot":     "forget",
+	"forgotten":  "forget",
+	"gave":       "give",
+	"given":      "give",
+	"went":       "go",
+	"gone":       "go",
+	"got":        "get",
+	"gotten":     "get",
+	"grew":       "grow",
+	"grown":      "grow",
+	"heard":      "hear",
+	"held":       "hold",
+	"kept":       "keep",
+	"knew":       "know",
+	"known":      "know",
+	"led":        "lead",
+	"lost":       "lose",
+	"made":       "make",
+	"meant":      "mean",
+	"met":        "meet",
+	"paid":       "pay",
+	"ran":        "run",
+	"rode":       "ride",
+	"ridden":     "ride",
+	"risen":      "rise",
+	"said":       "say",
+	"seen":       "see",
+	"sent":       "send",
+	"sold":       "sell",
+	"shook":      "shake",
+	"shaken":     "shake",
+	"sang":       "sing",
+	"sung":       "sing",
+	"sat":        "sit",
+	"slept":      "sleep",
+	"spoke":      "speak",
+	"spoken":     "speak",
+	"spent":      "spend",
+	"stood":      "stand",
+	"stole":      "steal",
+	"stolen":     "steal",
+	"swam":       "swim",
+	"swum":       "swim",
+	"taught":     "teach",
+	"told":       "tell",
+	"thought":    "think",
+	"threw":      "throw",
+	"thrown":     "throw",
+	"took":       "take",
+	"taken":      "take",
+	"understood": "understand",
+	"woke":       "wake",
+	"woken":      "wake",
+	"won":        "win",
+	"wore":       "wear",
+	"worn":       "wear",
+	"wrote":      "write",
+	"written":    "write",
+	// 不规则名词复数
+	"children":  "child",
+	"feet":      "foot",
+	"geese":     "goose",
+	"men":       "man",
+	"mice":      "mouse",
+	"people":    "person",
+	"teeth":     "tooth",
+	"women":     "woman",
+	"analyses":  "analysis",
+	"crises":    "crisis",
+	"criteria":  "criterion",
+	"phenomena": "phenomenon",
+	// 规则无法覆盖的常见变形
+	"goes":     "go",
+	"heroes":   "hero",
+	"potatoes": "potato",
+	"tomatoes": "tomato",
+	"echoes":   "echo",
+	"buses":    "bus",
+	"quizzes":  "quiz",
+	"movies":   "movie",
+	"cookies":  "cookie",
+	"used":     "use",
+	"using":    "use",
+	"caused":   "cause",
+	"causing":  "cause",
+	"created":  "create",
+	"creating": "create",
+}
+
+// lemmaExceptions 看似有屈折后缀、实为原形的单词
+var lemmaExceptions = map[string]bool{
+	"news": true, "series": true, "species": true, "always": true, "perhaps": true,
+	"thus": true, "bus": true, "gas": true, "yes": true, "this": true, "his": true,
+	"its": true, "us": true, "bias": true, "lens": true, "atlas": true,
+	"basis": true, "crisis": true, "analysis": true, "thesis": true, "physics": true,
+	"economics": true, "mathematics": true, "politics": true, "means": true,
+	"nothing": true, "something": true, "anything": true, "everything": true,
+	"morning": true, "evening": true, "during": true, "ceiling": true, "king": true,
+	"ring": true, "thing": true, "sing": true, "bring": true, "spring": true,
+	"string": true, "wing": true, "swing": true, "sting": true, "cling": true,
+	"building": true, "meeting": true, "feeling": true, "wedding": true,
+	"need": true, "seed": true, "feed": true, "speed": true, "bleed": true, "indeed": true,
+	"proceed": true, "succeed": true, "exceed": true, "breed": true, "greed": true,
+	"bed": true, "red": true, "shed": true, "wed": true, "hundred": true,
+	"sacred": true, "naked": true, "wicked": true, "kindred": true,
+}
+
+// Lemmatize 将英文单词还原为词元（原形）
+// 基于不规则变形表与后缀规则，适用于去重与词频统计，不保证语言学上完全正确
+func Lemmatize(word string) string {
+	w := strings.ToLower(strings.TrimSpace(word))
+	if lemmaExceptions[w] {
+		return w
+	}
+	if lemma, ok := irregularLemmas[w]; ok {
+		return lemma
+	}
+	if len(w) <= 3 {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "ied") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "ing") && vowelGroups(w[:len(w)-3]) > 0:
+		// 词干至少含一个元音：going -> go，而 fling、sling 保持原样
+		return restoreStem(w[:len(w)-3])
+	case strings.HasSuffix(w, "eed"):
+		// agreed -> agree
+		return w[:len(w)-1]
+	case strings.HasSuffix(w, "ed") && len(w) > 4:
+		return restoreStem(w[:len(w)-2])
+	case strings.HasSuffix(w, "es") && hasSibilantEnding(w[:len(w)-2]):
+		// boxes -> box, classes -> class, watches -> watch
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") &&
+		!strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// restoreStem 还原去掉 -ing / -ed 后的词干：去除双写辅音或补回词尾 e
+func restoreStem(stem string) string {
+	n := len(stem)
+	if n < 2 {
+		return stem
+	}
+	last, prev := stem[n-1], stem[n-2]
+
+	// running -> run, stopped -> stop（l/s/z/f 结尾的双写通常是原形，如 falling、missing）
+	if last == prev && !isVowel(last) && !strings.ContainsRune("lszf", rune(last)) {
+		return stem[:n-1]
+	}
+	// having -> have, dancing -> dance, arguing -> argue
+	if strings.ContainsRune("vcuz", rune(last)) && !strings.HasSuffix(stem, "ck") {
+		return stem + "e"
+	}
+	// making -> make, hoped -> hope：单音节且以“辅音-元音-辅音”结尾
+	if n >= 3 && !isVowel(last) && isVowel(prev) && !isVowel(stem[n-3]) &&
+		!strings.ContainsRune("wxy", rune(last)) && vowelGroups(stem) == 1 {
+		return stem + "e"
+	}
+	return stem
+}
+
+// hasSibilantEnding 判断词干是否以需要加 -es 构成复数的音结尾
+// 单个 s/z 结尾（houses、sizes）通常是原形自带 e，交由 -s 规则处理
+func hasSibilantEnding(stem string) bool {
+	for _, suffix := range []string{"ss", "x", "zz", "ch", "sh"} {
+		if strings.HasSuffix(stem, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// vowelGroups 统计元音组数量，用于粗略估计音节数
+func vowelGroups(s string) int {
+	groups := 0
+	inVowel := false
+	for i := 0; i < len(s); i++ {
+		v := isVowel(s[i])
+		if v && !inVowel {
+			groups++
+		}
+		inVowel = v
+	}
+	return groups
+}
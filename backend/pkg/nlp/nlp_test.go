@@ -0,0 +1,149 @@
+// pkg/nlp/nlp_test.go
+package nlp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLemmatize(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"Running", "run"},
+		{"running", "run"},
+		{"ran", "run"},
+		{"studies", "study"},
+		{"studied", "study"},
+		{"making", "make"},
+		{"hoped", "hope"},
+		{"stopped", "stop"},
+		{"falling", "fall"},
+		{"having", "have"},
+		{"boxes", "box"},
+		{"classes", "class"},
+		{"houses", "house"},
+		{"agreed", "agree"},
+		{"children", "child"},
+		{"visiting", "visit"},
+		{"going", "go"},
+		{"doing", "do"},
+		{"seeing", "see"},
+		{"fling", "fling"},
+		{"news", "news"},
+		{"morning", "morning"},
+		{"analysis", "analysis"},
+		{"apple", "apple"},
+	}
+	for _, tt := range tests {
+		if got := Lemmatize(tt.word); got != tt.want {
+			t.Errorf("Lemmatize(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	text := "The cat sat. Did it run?  It ran!\n\nNew paragraph without period"
+	got := SplitSentences(text)
+	want := []string{"The cat sat.", "Did it run?", "It ran!", "New paragraph without period"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected sentences: %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sentence %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExtractVocabulary(t *testing.T) {
+	text := "Researchers observed the glaciers. The glacier was melting quickly, and researchers in Norway " +
+		"were observing it daily. NASA didn't comment."
+
+	got := ExtractVocabulary(text, ExtractOptions{})
+	byLemma := make(map[string]*Candidate, len(got))
+	for _, c := range got {
+		byLemma[c.Lemma] = c
+	}
+
+	for _, dropped := range []string{"the", "norway", "nasa", "didn't", "didn"} {
+		if _, ok := byLemma[dropped]; ok {
+			t.Fatalf("expected %q to be dropped, got %+v", dropped, byLemma[dropped])
+		}
+	}
+
+	glacier, ok := byLemma["glacier"]
+	if !ok {
+		t.Fatalf("expected glacier in result: %+v", got)
+	}
+	if glacier.Count != 2 {
+		t.Fatalf("unexpected glacier count: %d", glacier.Count)
+	}
+	if glacier.Sentence != "Researchers observed the glaciers." {
+		t.Fatalf("unexpected glacier sentence: %q", glacier.Sentence)
+	}
+
+	if _, ok := byLemma["observe"]; !ok {
+		t.Fatalf("expected observed/observing to be lemmatized to observe: %+v", got)
+	}
+}
+
+func TestExtractVocabularyOrder(t *testing.T) {
+	// 按文章内出现次数降序，次数相同时按首次出现的先后
+	text := "Glaciers melt slowly. A glacier near the harbor melted. The harbor closed while the glacier retreated."
+
+	var got []string
+	for _, c := range ExtractVocabulary(text, ExtractOptions{}) {
+		got = append(got, c.Lemma)
+	}
+	want := []string{"glacier", "melt", "harbor", "slowly", "near", "close", "retreat"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("ExtractVocabulary order = %v, want %v", got, want)
+	}
+}
+
+func TestRankByFrequency(t *testing.T) {
+	candidates := []*Candidate{
+		{Lemma: "glacier", Count: 3, Rank: 9000},
+		{Lemma: "melt", Count: 2, Rank: 4200},
+		{Lemma: "moraine", Count: 2},
+		{Lemma: "harbor", Count: 1, Rank: 4200},
+		{Lemma: "near", Count: 1, Rank: 300},
+		{Lemma: "serac", Count: 1},
+	}
+	RankByFrequency(candidates)
+
+	var got []string
+	for _, c := range candidates {
+		got = append(got, c.Lemma)
+	}
+	// 语料排名优先，排名相同时按文章内次数，没有排名的排在最后
+	want := []string{"near", "melt", "harbor", "glacier", "moraine", "serac"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("RankByFrequency order = %v, want %v", got, want)
+	}
+}
+
+func TestLemmaKey(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"Running", "run"},
+		{" running ", "run"},
+		{"ran", "run"},
+		{"ＲＵＮ", "run"},
+		{"don’t", "don't"},
+		{"Looking  Forward", "look forward"},
+	}
+	for _, tt := range tests {
+		if got := LemmaKey(tt.word); got != tt.want {
+			t.Errorf("LemmaKey(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+
+	if got := NormalizeSurface(" Ｃafé au  lait "); got != "Café au lait" {
+		t.Errorf("NormalizeSurface kept unexpected form: %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+// pkg/nlp/normalize.go
+package nlp
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// apostropheReplacer 统一各类撇号与连字符，避免全角/弯引号造成的重复
+var apostropheReplacer = strings.NewReplacer(
+	"’", "'", "‘", "'", "`", "'", "´", "'",
+	"‐", "-", "‑", "-", "–", "-", "—", "-",
+)
+
+// NormalizeSurface 规范化单词的展示形式：Unicode NFKC、统一标点、合并空白，保留大小写
+func NormalizeSurface(word string) string {
+	w := norm.NFKC.String(word)
+	w = apostropheReplacer.Replace(w)
+	return strings.Join(strings.Fields(w), " ")
+}
+
+// Fold 在规范化基础上做大小写折叠（case folding）
+func Fold(word string) string {
+	return cases.Fold().String(NormalizeSurface(word))
+}
+
+// LemmaKey 生成用于去重的词元键：规范化、大小写折叠后逐词还原原形
+// 例如 "Running"、"running"、"ran" 均得到 "run"；词组按单词分别还原
+func LemmaKey(word string) string {
+	parts := strings.Fields(Fold(word))
+	for i, p := range parts {
+		parts[i] = Lemmatize(p)
+	}
+	return strings.Join(parts, " ")
+}
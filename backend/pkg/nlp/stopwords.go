@@ -0,0 +1,43 @@
+// pkg/nlp/stopwords.go
+package nlp
+
+// stopwords 英文停用词（按词元存储），这些词没有单独学习的价值
+var stopwords = toSet(
+	"a", "about", "above", "after", "again", "against", "all", "also", "an", "and", "any", "as", "at",
+	"be", "because", "before", "below", "between", "both", "but", "by",
+	"can", "could",
+	"do", "down",
+	"each", "either", "else", "even", "ever", "every",
+	"few", "for", "from", "further",
+	"get", "go",
+	"have", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how", "however",
+	"i", "if", "in", "into", "it", "its", "itself",
+	"just",
+	"like",
+	"many", "may", "me", "might", "mine", "more", "most", "much", "must", "my", "myself",
+	"neither", "no", "nor", "not", "now",
+	"of", "off", "often", "on", "once", "one", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own",
+	"quite",
+	"rather",
+	"same", "say", "she", "should", "so", "some", "such",
+	"than", "that", "the", "their", "theirs", "them", "themselves", "then", "there", "these", "they",
+	"this", "those", "through", "to", "too",
+	"under", "until", "up", "upon", "us",
+	"very",
+	"we", "well", "what", "when", "where", "whether", "which", "while", "who", "whom", "whose", "why",
+	"will", "with", "within", "without", "would",
+	"yet", "you", "your", "yours", "yourself", "yourselves",
+)
+
+// IsStopword 判断词元是否为停用词
+func IsStopword(lemma string) bool {
+	return stopwords[lemma]
+}
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
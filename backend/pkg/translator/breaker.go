@@ -0,0 +1,142 @@
+package translator
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+var (
+	// ErrCircuitOpen 提供方熔断中，未发起请求
+	ErrCircuitOpen = errors.New("circuit open")
+	// ErrUnavailable 没有提供方给出结果且至少一个提供方处于熔断，调用方应稍后重试
+	ErrUnavailable = errors.New("translator providers unavailable")
+)
+
+// 熔断器状态
+const (
+	BreakerClosed   = "closed"    // 正常
+	BreakerOpen     = "open"      // 熔断，直接失败
+	BreakerHalfOpen = "half_open" // 冷却结束，放行一次探测请求
+)
+
+// Breaker 提供方熔断器：连续失败达到阈值后熔断，冷却期内直接失败；
+// 冷却结束后放行一次探测，成功则恢复，失败则重新熔断。
+type Breaker struct {
+	mu          sync.Mutex
+	threshold   int
+	cooldown    time.Duration
+	state       string
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	lastError   string
+	lastFailure time.Time
+}
+
+// NewBreaker 创建熔断器，非正数参数使用默认值（连续 5 次失败、冷却 30 秒）
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &Breaker{threshold: threshold, cooldown: cooldown, state: BreakerClosed}
+}
+
+// Allow 判断是否可以发起请求；允许时调用方必须随后调用 Done
+func (b *Breaker) Allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Done 记录请求结果：成功与未收录视为提供方正常，其余错误计为失败
+func (b *Breaker) Done(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case err == nil || errors.Is(err, ErrNotFound):
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+	default:
+		b.failures++
+		b.lastError = err.Error()
+		b.lastFailure = time.Now()
+		b.probing = false
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		}
+	}
+}
+
+// Release 调用方取消请求时使用：不记录结果，仅释放探测名额
+func (b *Breaker) Release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// BreakerSnapshot 熔断器当前状态
+type BreakerSnapshot struct {
+	State               string
+	ConsecutiveFailures int
+	LastError           string
+	LastFailureAt       time.Time
+	RetryAt             time.Time // 熔断时下一次允许探测的时间
+}
+
+// Snapshot 返回熔断器当前状态
+func (b *Breaker) Snapshot() BreakerSnapshot {
+	if b == nil {
+		return BreakerSnapshot{State: BreakerClosed}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := BreakerSnapshot{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+		LastFailureAt:       b.lastFailure,
+	}
+	if b.state == BreakerOpen {
+		s.RetryAt = b.openedAt.Add(b.cooldown)
+		if !time.Now().Before(s.RetryAt) {
+			s.State = BreakerHalfOpen
+		}
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAfterThresholdAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	b := NewBreaker(2, 20*time.Millisecond)
+	down := errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		if !b.Allow() {
+			t.Fatalf("request %d should be allowed while closed", i)
+		}
+		b.Done(down)
+	}
+	if b.Allow() {
+		t.Fatal("breaker should fail fast after reaching the threshold")
+	}
+	if s := b.Snapshot(); s.State != BreakerOpen || s.ConsecutiveFailures != 2 || s.RetryAt.IsZero() {
+		t.Fatalf("unexpected snapshot: %+v", s)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("breaker should allow a probe after cooldown")
+	}
+	if b.Allow() {
+		t.Fatal("only one probe should be allowed while half-open")
+	}
+	b.Done(ErrNotFound)
+	if s := b.Snapshot(); s.State != BreakerClosed || s.ConsecutiveFailures != 0 {
+		t.Fatalf("breaker should close after a successful probe: %+v", s)
+	}
+}
+
+func TestChainReportsUnavailableWhenProviderCircuitIsOpen(t *testing.T) {
+	t.Parallel()
+
+	down := &stubTranslator{err: errors.New("503")}
+	local := &stubTranslator{err: ErrNotFound}
+	chain := NewChain(
+		Provider{Name: "online", Translator: down, Breaker: NewBreaker(1, time.Minute)},
+		Provider{Name: "local", Translator: local},
+	)
+
+	ctx := context.Background()
+	if _, err := chain.Translate(ctx, "apple"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable once the circuit trips, got %v", err)
+	}
+	if _, err := chain.Translate(ctx, "pear"); !errors.Is(err, ErrUnavailable) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected fail-fast ErrUnavailable, got %v", err)
+	}
+	if down.calls != 1 {
+		t.Fatalf("open circuit should skip the provider, got %d calls", down.calls)
+	}
+	if local.calls != 2 {
+		t.Fatalf("remaining providers should still be tried, got %d calls", local.calls)
+	}
+
+	health := chain.Health()
+	if len(health) != 2 || health[0].State != BreakerOpen || health[1].State != BreakerClosed {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+}
@@ -0,0 +1,173 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Option 提供方的通用配置
+type Option func(*options)
+
+type options struct {
+	timeout    time.Duration
+	maxRetries int
+	limiter    *Limiter
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{timeout: defaultTimeout, maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithTimeout 设置单次请求超时，非正数时使用默认值
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithMaxRetries 设置遇到 429/5xx 时的最大重试次数，负数时不重试
+func WithMaxRetries(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxRetries = n
+	}
+}
+
+// WithLimiter 设置共享的限流器，每次请求（含重试）前都会等待令牌
+func WithLimiter(l *Limiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
+// Provider 具名的翻译提供方，Breaker 为 nil 时不熔断
+type Provider struct {
+	Name       string
+	Translator Translator
+	Breaker    *Breaker
+}
+
+// Chain 按顺序尝试多个提供方，未收录或出错时回退到下一个
+type Chain struct {
+	providers []Provider
+}
+
+// NewChain 创建链式翻译器
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{providers: providers}
+}
+
+// Providers 返回提供方名称（按尝试顺序）
+func (c *Chain) Providers() []string {
+	names := make([]string, 0, len(c.providers))
+	for _, p := range c.providers {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// Close 关闭持有本地文件的提供方
+func (c *Chain) Close() error {
+	var errs []error
+	for _, p := range c.providers {
+		if closer, ok := p.Translator.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Translate 依次调用提供方，返回第一个成功的结果并记录提供方名称；熔断中的提供方直接跳过。
+// 全部未收录时返回 ErrNotFound；没有结果且有提供方处于熔断时返回 ErrUnavailable，
+// 否则汇总各提供方的错误。ctx 结束时立即返回，不再回退。
+func (c *Chain) Translate(ctx context.Context, word string) (*WordDetail, error) {
+	var errs []error
+	unavailable := false
+	for _, p := range c.providers {
+		if !p.Breaker.Allow() {
+			unavailable = true
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, ErrCircuitOpen))
+			continue
+		}
+		detail, err := p.Translator.Translate(ctx, word)
+		if ctx.Err() != nil {
+			p.Breaker.Release()
+			return nil, ctx.Err()
+		}
+		p.Breaker.Done(err)
+		if err == nil {
+			if detail.Provider == "" {
+				detail.Provider = p.Name
+			}
+			return detail, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+			if p.Breaker.Snapshot().State == BreakerOpen {
+				unavailable = true
+			}
+		}
+	}
+	if unavailable {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, errors.Join(errs...))
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.TrimSpace(word))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// FrequencyRanker 可查询通用语料词频排名的翻译器，排名越小越常用，0 表示未知
+type FrequencyRanker interface {
+	FrequencyRank(word string) (int, error)
+}
+
+// FrequencyRank 依次询问支持词频排名的提供方，返回第一个已知的排名；都不支持或未收录时返回 0
+func (c *Chain) FrequencyRank(word string) (int, error) {
+	for _, p := range c.providers {
+		ranker, ok := p.Translator.(FrequencyRanker)
+		if !ok {
+			continue
+		}
+		rank, err := ranker.FrequencyRank(word)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		if rank > 0 {
+			return rank, nil
+		}
+	}
+	return 0, nil
+}
+
+// ProviderHealth 提供方的健康状态
+type ProviderHealth struct {
+	Name string
+	BreakerSnapshot
+}
+
+// HealthReporter 可报告提供方健康状态的翻译器
+type HealthReporter interface {
+	Health() []ProviderHealth
+}
+
+// Health 返回各提供方的熔断状态（按尝试顺序）
+func (c *Chain) Health() []ProviderHealth {
+	health := make([]ProviderHealth, 0, len(c.providers))
+	for _, p := range c.providers {
+		health = append(health, ProviderHealth{Name: p.Name, BreakerSnapshot: p.Breaker.Snapshot()})
+	}
+	return health
+}
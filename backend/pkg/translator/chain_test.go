@@ -0,0 +1,77 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/pkg/meaning"
+)
+
+type stubTranslator struct {
+	detail *WordDetail
+	err    error
+	calls  int
+}
+
+func (s *stubTranslator) Translate(_ context.Context, word string) (*WordDetail, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &WordDetail{Word: word, Meaning: s.detail.Meaning, Provider: s.detail.Provider}, nil
+}
+
+func TestChainFallsBackToNextProvider(t *testing.T) {
+	t.Parallel()
+
+	flaky := &stubTranslator{err: errors.New("connection reset")}
+	missing := &stubTranslator{err: ErrNotFound}
+	ok := &stubTranslator{detail: &WordDetail{Meaning: meaning.ParseGlosses("n. 苹果")}}
+	unused := &stubTranslator{detail: &WordDetail{}}
+
+	chain := NewChain(
+		Provider{Name: "flaky", Translator: flaky},
+		Provider{Name: "missing", Translator: missing},
+		Provider{Name: "local", Translator: ok},
+		Provider{Name: "unused", Translator: unused},
+	)
+	got, err := chain.Translate(context.Background(), "apple")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if got.Provider != "local" {
+		t.Fatalf("unexpected provider: %s", got.Provider)
+	}
+	if flaky.calls != 1 || missing.calls != 1 || unused.calls != 0 {
+		t.Fatalf("unexpected calls: flaky=%d missing=%d unused=%d", flaky.calls, missing.calls, unused.calls)
+	}
+}
+
+func TestChainAllNotFound(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain(
+		Provider{Name: "a", Translator: &stubTranslator{err: ErrNotFound}},
+		Provider{Name: "b", Translator: &stubTranslator{err: ErrNotFound}},
+	)
+	if _, err := chain.Translate(context.Background(), "qwzx"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChainReportsProviderErrors(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain(
+		Provider{Name: "a", Translator: &stubTranslator{err: ErrNotFound}},
+		Provider{Name: "b", Translator: &stubTranslator{err: errors.New("status: 503")}},
+	)
+	_, err := chain.Translate(context.Background(), "apple")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected provider error, got %v", err)
+	}
+	if err.Error() != "b: status: 503" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package translator
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"backend/pkg/meaning"
+)
+
+// ProviderECDICT 本地 ECDICT 词典的提供方名称
+const ProviderECDICT = "ecdict"
+
+// ecdictTags ECDICT tag 列的考试标签
+var ecdictTags = map[string]string{
+	"zk":    "中考",
+	"gk":    "高考",
+	"cet4":  "CET4",
+	"cet6":  "CET6",
+	"ky":    "考研",
+	"toefl": "TOEFL",
+	"ielts": "IELTS",
+	"gre":   "GRE",
+}
+
+// ECDICTTranslator 基于本地 ECDICT CSV（https://github.com/skywind3000/ECDICT）的离线词典。
+// 首次加载时在 CSV 旁生成 .vidx 索引，之后按索引随机读取，不会把整个词库载入内存。
+type ECDICTTranslator struct {
+	f       *os.File
+	index   *diskIndex
+	columns map[string]int
+}
+
+// NewECDICTTranslator 打开 ECDICT CSV 文件，索引不存在或过期时自动重建
+func NewECDICTTranslator(path string) (*ECDICTTranslator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ecdict: %w", err)
+	}
+	header, err := csv.NewReader(f).Read()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read ecdict header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\uFEFF")] = i
+	}
+	if _, ok := columns["word"]; !ok {
+		f.Close()
+		return nil, fmt.Errorf("ecdict header has no word column: %v", header)
+	}
+
+	t := &ECDICTTranslator{f: f, columns: columns}
+	t.index, err = openDiskIndex(path, t.scan)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to index ecdict: %w", err)
+	}
+	return t, nil
+}
+
+// scan 遍历 CSV 生成索引项，同一键优先保留与键完全一致（小写）的词条
+func (t *ECDICTTranslator) scan() ([]indexEntry, error) {
+	if _, err := t.f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r := newECDICTReader(t.f)
+	if _, err := r.Read(); err != nil {
+		return nil, err
+	}
+
+	wordCol := t.columns["word"]
+	entries := make([]indexEntry, 0, 1<<16)
+	positions := make(map[string]int)
+	for {
+		start := r.InputOffset()
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if wordCol >= len(record) {
+			continue
+		}
+		word := strings.TrimSpace(record[wordCol])
+		key := normalizeKey(word)
+		entry := indexEntry{key: key, offset: start, size: r.InputOffset() - start}
+		if i, ok := positions[key]; ok {
+			if word == key {
+				entries[i] = entry
+			}
+			continue
+		}
+		positions[key] = len(entries)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Translate 查询本地词典
+func (t *ECDICTTranslator) Translate(ctx context.Context, word string) (*WordDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := normalizeKey(word)
+	if key == "" {
+		return nil, fmt.Errorf("word is empty")
+	}
+	record, err := t.record(key)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	detail := t.detail(record)
+	if detail == nil {
+		return nil, fmt.Errorf("%w: no definitions found for word: %s", ErrNotFound, key)
+	}
+	return detail, nil
+}
+
+// FrequencyRank 返回单词的语料词频排名（frq 列，缺失时用 bnc 列），未收录或没有词频时返回 0
+func (t *ECDICTTranslator) FrequencyRank(word string) (int, error) {
+	key := normalizeKey(word)
+	if key == "" {
+		return 0, nil
+	}
+	record, err := t.record(key)
+	if err != nil || record == nil {
+		return 0, err
+	}
+	return t.frequency(record), nil
+}
+
+// record 按索引读取词条，未收录时返回 nil
+func (t *ECDICTTranslator) record(key string) ([]string, error) {
+	entry, ok, err := t.index.lookup(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup ecdict: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	buf := make([]byte, entry.size)
+	if _, err := t.f.ReadAt(buf, entry.offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read ecdict record: %w", err)
+	}
+	record, err := newECDICTReader(bytes.NewReader(buf)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ecdict record: %w", err)
+	}
+	return record, nil
+}
+
+// field 返回词条中指定列的值，列不存在时返回空
+func (t *ECDICTTranslator) field(record []string, name string) string {
+	if i, ok := t.columns[name]; ok && i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+// frequency 返回词条的词频排名：优先 frq（COCA），缺失时用 bnc
+func (t *ECDICTTranslator) frequency(record []string) int {
+	frequency, _ := strconv.Atoi(t.field(record, "frq"))
+	if frequency <= 0 {
+		frequency, _ = strconv.Atoi(t.field(record, "bnc"))
+	}
+	return frequency
+}
+
+// detail 将 ECDICT 词条转换为单词详情：translation 列作为中文翻译，definition 列作为英文释义，
+// 按词性分组；两者都为空时返回 nil
+func (t *ECDICTTranslator) detail(record []string) *WordDetail {
+	field := func(name string) string { return t.field(record, name) }
+	var m meaning.Meaning
+	for _, g := range meaning.SplitGlosses(unescapeGlosses(field("translation"))) {
+		m.AddTranslation(g.POS, g.Text)
+	}
+	for _, g := range meaning.SplitGlosses(unescapeGlosses(field("definition"))) {
+		m.AddDefinition(g.POS, g.Text)
+	}
+	if m.IsEmpty() {
+		return nil
+	}
+	tags := parseECDICTTags(field("tag"))
+	if len(tags) > 0 {
+		m.Tags = tags
+	}
+
+	phonetic := field("phonetic")
+	if phonetic != "" {
+		phonetic = "/" + strings.Trim(phonetic, "/[]") + "/"
+	}
+	return &WordDetail{
+		Word:      field("word"),
+		Phonetic:  phonetic,
+		Meaning:   m,
+		Provider:  ProviderECDICT,
+		Frequency: t.frequency(record),
+		Tags:      tags,
+	}
+}
+
+// Close 关闭词典文件
+func (t *ECDICTTranslator) Close() error {
+	t.index.Close()
+	return t.f.Close()
+}
+
+func newECDICTReader(r io.Reader) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	return cr
+}
+
+// unescapeGlosses ECDICT 以字面量 \n 分隔释义各行
+func unescapeGlosses(text string) string {
+	text = strings.ReplaceAll(text, `\r`, "")
+	return strings.ReplaceAll(text, `\n`, "\n")
+}
+
+// parseECDICTTags 转换 tag 列（空格分隔，如 "cet4 cet6 ielts"），未知标签保持原样
+func parseECDICTTags(text string) []string {
+	fields := strings.Fields(text)
+	tags := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if name, ok := ecdictTags[f]; ok {
+			tags = append(tags, name)
+		} else {
+			tags = append(tags, f)
+		}
+	}
+	return tags
+}
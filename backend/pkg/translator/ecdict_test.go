@@ -0,0 +1,135 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"backend/pkg/meaning"
+)
+
+const sampleECDICT = `word,phonetic,definition,translation,pos,collins,oxford,tag,bnc,frq,exchange,detail,audio
+Apple,,n. a company,n. 苹果公司,,,,,,,,,
+abandon,ə'bændən,"v. forsake, leave behind\nn. the trait of lacking restraint",vt. 放弃；抛弃\nn. 放任,,4,1,cet4 cet6 ielts,1857,2346,,,
+apple,'æpl,n. fruit with red or yellow or green skin,n. 苹果,,,,zk gk,2446,1888,,,
+zzzz,,,,,,,,,,,,
+`
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestECDICTTranslatorTranslate(t *testing.T) {
+	t.Parallel()
+
+	path := writeTempFile(t, "ecdict.csv", sampleECDICT)
+	tr, err := NewECDICTTranslator(path)
+	if err != nil {
+		t.Fatalf("NewECDICTTranslator returned error: %v", err)
+	}
+	defer tr.Close()
+
+	got, err := tr.Translate(context.Background(), "  Abandon ")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if got.Phonetic != "/ə'bændən/" || got.Frequency != 2346 || got.Provider != ProviderECDICT {
+		t.Fatalf("unexpected detail: %+v", got)
+	}
+	if !reflect.DeepEqual(got.Tags, []string{"CET4", "CET6", "IELTS"}) {
+		t.Fatalf("unexpected tags: %v", got.Tags)
+	}
+	wantSenses := []meaning.Sense{
+		{POS: "vt.", Translations: []string{"放弃；抛弃"}},
+		{POS: "n.", Definitions: []string{"the trait of lacking restraint"}, Translations: []string{"放任"}},
+		{POS: "v.", Definitions: []string{"forsake, leave behind"}},
+	}
+	if !reflect.DeepEqual(got.Meaning.Senses, wantSenses) {
+		t.Fatalf("unexpected senses: %+v", got.Meaning.Senses)
+	}
+
+	// 大小写冲突时优先小写词条
+	apple, err := tr.Translate(context.Background(), "APPLE")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if apple.Word != "apple" {
+		t.Fatalf("unexpected entry: %s", apple.Word)
+	}
+
+	for _, word := range []string{"zzzz", "missing", "aaa"} {
+		if _, err := tr.Translate(context.Background(), word); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Translate(%q) expected ErrNotFound, got %v", word, err)
+		}
+	}
+}
+
+func TestECDICTFrequencyRank(t *testing.T) {
+	t.Parallel()
+
+	path := writeTempFile(t, "ecdict.csv", sampleECDICT)
+	tr, err := NewECDICTTranslator(path)
+	if err != nil {
+		t.Fatalf("NewECDICTTranslator returned error: %v", err)
+	}
+	defer tr.Close()
+
+	// 本地词典之前的提供方不支持词频排名时跳过
+	chain := NewChain(
+		Provider{Name: "remote", Translator: &stubTranslator{detail: &WordDetail{}}},
+		Provider{Name: ProviderECDICT, Translator: tr},
+	)
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"Abandon", 2346}, // frq
+		{"apple", 1888},
+		{"zzzz", 0}, // 没有词频
+		{"missing", 0},
+	}
+	for _, tt := range tests {
+		got, err := chain.FrequencyRank(tt.word)
+		if err != nil {
+			t.Fatalf("FrequencyRank(%q) returned error: %v", tt.word, err)
+		}
+		if got != tt.want {
+			t.Errorf("FrequencyRank(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestECDICTTranslatorRebuildsStaleIndex(t *testing.T) {
+	t.Parallel()
+
+	path := writeTempFile(t, "ecdict.csv", sampleECDICT)
+	tr, err := NewECDICTTranslator(path)
+	if err != nil {
+		t.Fatalf("NewECDICTTranslator returned error: %v", err)
+	}
+	tr.Close()
+	if _, err := os.Stat(path + indexSuffix); err != nil {
+		t.Fatalf("index not written: %v", err)
+	}
+
+	updated := sampleECDICT + "banana,bə'nɑːnə,,n. 香蕉,,,,,,,,,\n"
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		t.Fatalf("rewrite csv: %v", err)
+	}
+	tr, err = NewECDICTTranslator(path)
+	if err != nil {
+		t.Fatalf("NewECDICTTranslator returned error: %v", err)
+	}
+	defer tr.Close()
+	if _, err := tr.Translate(context.Background(), "banana"); err != nil {
+		t.Fatalf("Translate after rebuild returned error: %v", err)
+	}
+}
@@ -1,7 +1,9 @@
 package translator
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,28 +11,44 @@ import (
 	"strings"
 	"time"
 
+	"backend/pkg/meaning"
+
 	"github.com/go-kratos/kratos/v2/log"
 )
 
-const defaultFreeDictionaryBaseURL = "https://freedictionaryapi.com"
+const (
+	defaultFreeDictionaryBaseURL = "https://freedictionaryapi.com"
+	defaultTimeout               = 10 * time.Second
+)
+
+// ProviderFreeDictionary Free Dictionary API 的提供方名称
+const ProviderFreeDictionary = "freedictionary"
+
+// ErrNotFound 提供方没有收录该单词，链式翻译器据此回退到下一个提供方
+var ErrNotFound = errors.New("word not found")
 
 // WordDetail 单词详细信息
 type WordDetail struct {
-	Word     string                 `json:"word"`
-	Phonetic string                 `json:"phonetic"`
-	Meaning  map[string]interface{} `json:"meaning"`
-	Example  string                 `json:"example"`
+	Word      string          `json:"word"`
+	Phonetic  string          `json:"phonetic"`
+	Meaning   meaning.Meaning `json:"meaning"`
+	Example   string          `json:"example"`
+	Provider  string          `json:"provider"`            // 给出结果的提供方
+	Frequency int             `json:"frequency,omitempty"` // 词频排名，0 表示未知
+	Tags      []string        `json:"tags,omitempty"`      // 考试标签，如 CET4、IELTS
 }
 
 // Translator 翻译接口
 type Translator interface {
-	Translate(word string) (*WordDetail, error)
+	Translate(ctx context.Context, word string) (*WordDetail, error)
 }
 
 // FreeDictionaryTranslator Free Dictionary API 实现
 type FreeDictionaryTranslator struct {
 	BaseURL string
 	client  *http.Client
+	retry   retryPolicy
+	limiter *Limiter
 }
 
 type freeDictionaryResponse struct {
@@ -47,7 +65,11 @@ type freeDictionaryEntry struct {
 	Senses []struct {
 		Definition string   `json:"definition"`
 		Examples   []string `json:"examples"`
+		Synonyms   []string `json:"synonyms"`
+		Antonyms   []string `json:"antonyms"`
 	} `json:"senses"`
+	Synonyms []string `json:"synonyms"`
+	Antonyms []string `json:"antonyms"`
 }
 
 type freeDictionaryError struct {
@@ -55,61 +77,67 @@ type freeDictionaryError struct {
 	Message string `json:"message"`
 }
 
-// NewFreeDictionaryTranslator 创建 Free Dictionary 翻译器
-func NewFreeDictionaryTranslator(baseURL string) *FreeDictionaryTranslator {
+// NewFreeDictionaryTranslator 创建 Free Dictionary 翻译器，baseURL 为空时使用官方地址
+func NewFreeDictionaryTranslator(baseURL string, opts ...Option) *FreeDictionaryTranslator {
 	if strings.TrimSpace(baseURL) == "" {
 		baseURL = defaultFreeDictionaryBaseURL
 	}
+	o := newOptions(opts)
 	return &FreeDictionaryTranslator{
 		BaseURL: strings.TrimRight(baseURL, "/"),
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: o.timeout,
 		},
+		retry:   retryPolicy{maxRetries: o.maxRetries, baseDelay: defaultRetryDelay},
+		limiter: o.limiter,
 	}
 }
 
 // Translate 翻译单词
-func (t *FreeDictionaryTranslator) Translate(word string) (*WordDetail, error) {
+func (t *FreeDictionaryTranslator) Translate(ctx context.Context, word string) (*WordDetail, error) {
 	normalized := strings.TrimSpace(word)
 	if normalized == "" {
 		return nil, fmt.Errorf("word is empty")
 	}
 
-	resp, err := t.requestEntries(normalized)
+	resp, err := t.requestEntries(ctx, normalized)
 	if err != nil {
 		return nil, err
 	}
 	if len(resp.Entries) == 0 {
-		return nil, fmt.Errorf("word not found: %s", normalized)
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, normalized)
 	}
 
 	phonetic := pickPhonetic(resp.Entries)
 
-	definitions := make([]map[string]string, 0)
+	// 同一词性的多个条目合并为一个义项
+	var m meaning.Meaning
 	var example string
 	for _, entry := range resp.Entries {
+		added := false
 		for _, sense := range entry.Senses {
 			text := strings.TrimSpace(sense.Definition)
 			if text == "" {
 				continue
 			}
-			item := map[string]string{"text": text}
-			if strings.TrimSpace(entry.PartOfSpeech) != "" {
-				item["pos"] = entry.PartOfSpeech
-			}
-			definitions = append(definitions, item)
-			if example == "" {
-				for _, ex := range sense.Examples {
-					if strings.TrimSpace(ex) != "" {
-						example = ex
-						break
-					}
-				}
+			added = true
+			m.AddDefinition(entry.PartOfSpeech, text)
+			s := m.Sense(entry.PartOfSpeech)
+			s.Examples = appendTrimmed(s.Examples, sense.Examples...)
+			s.Synonyms = appendTrimmed(s.Synonyms, sense.Synonyms...)
+			s.Antonyms = appendTrimmed(s.Antonyms, sense.Antonyms...)
+			if example == "" && len(s.Examples) > 0 {
+				example = s.Examples[0]
 			}
 		}
+		if added {
+			s := m.Sense(entry.PartOfSpeech)
+			s.Synonyms = appendTrimmed(s.Synonyms, entry.Synonyms...)
+			s.Antonyms = appendTrimmed(s.Antonyms, entry.Antonyms...)
+		}
 	}
-	if len(definitions) == 0 {
-		return nil, fmt.Errorf("no definitions found for word: %s", normalized)
+	if m.IsEmpty() {
+		return nil, fmt.Errorf("%w: no definitions found for word: %s", ErrNotFound, normalized)
 	}
 
 	wordInResp := strings.TrimSpace(resp.Word)
@@ -120,21 +148,31 @@ func (t *FreeDictionaryTranslator) Translate(word string) (*WordDetail, error) {
 	return &WordDetail{
 		Word:     wordInResp,
 		Phonetic: phonetic,
-		Meaning: map[string]interface{}{
-			"definitions": definitions,
-		},
-		Example: example,
+		Meaning:  m,
+		Example:  example,
+		Provider: ProviderFreeDictionary,
 	}, nil
 }
 
-func (t *FreeDictionaryTranslator) requestEntries(word string) (*freeDictionaryResponse, error) {
+func (t *FreeDictionaryTranslator) requestEntries(ctx context.Context, word string) (*freeDictionaryResponse, error) {
 	escaped := url.PathEscape(word)
 	endpoint := t.BaseURL + "/api/v1/entries/en/" + escaped
-	return t.tryEndpoint(endpoint)
+
+	var parsed *freeDictionaryResponse
+	err := t.retry.do(ctx, t.limiter, func() error {
+		var err error
+		parsed, err = t.tryEndpoint(ctx, endpoint)
+		return err
+	})
+	return parsed, err
 }
 
-func (t *FreeDictionaryTranslator) tryEndpoint(endpoint string) (*freeDictionaryResponse, error) {
-	resp, err := t.client.Get(endpoint)
+func (t *FreeDictionaryTranslator) tryEndpoint(ctx context.Context, endpoint string) (*freeDictionaryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build free dictionary request: %w", err)
+	}
+	resp, err := t.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call free dictionary api: %w", err)
 	}
@@ -149,13 +187,17 @@ func (t *FreeDictionaryTranslator) tryEndpoint(endpoint string) (*freeDictionary
 		log.Warnf("free dictionary api returned 404 endpoint=%s body_preview=%q", endpoint, truncateBodyPreview(body))
 		var apiErr freeDictionaryError
 		if json.Unmarshal(body, &apiErr) == nil && strings.TrimSpace(apiErr.Title) != "" {
-			return nil, fmt.Errorf("word not found")
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, apiErr.Title)
 		}
-		return nil, fmt.Errorf("word not found")
+		return nil, ErrNotFound
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("free dictionary api status: %d", resp.StatusCode)
+		return nil, &StatusError{
+			Provider:   ProviderFreeDictionary,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
 	var parsed freeDictionaryResponse
@@ -165,6 +207,27 @@ func (t *FreeDictionaryTranslator) tryEndpoint(endpoint string) (*freeDictionary
 	return &parsed, nil
 }
 
+// appendTrimmed 追加非空且未出现过的值
+func appendTrimmed(list []string, values ...string) []string {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		dup := false
+		for _, existing := range list {
+			if existing == v {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func pickPhonetic(entries []freeDictionaryEntry) string {
 	for _, entry := range entries {
 		for _, p := range entry.Pronunciations {
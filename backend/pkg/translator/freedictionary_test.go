@@ -1,10 +1,17 @@
 package translator
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"backend/pkg/meaning"
 )
 
 func TestFreeDictionaryTranslatorTranslateSuccess(t *testing.T) {
@@ -27,9 +34,11 @@ func TestFreeDictionaryTranslatorTranslateSuccess(t *testing.T) {
 					"senses":[
 						{
 							"definition":"The way a living creature behaves.",
-							"examples":["Her behavior changed over time."]
+							"examples":["Her behavior changed over time."],
+							"synonyms":["conduct"]
 						}
-					]
+					],
+					"antonyms":["misbehavior"]
 				}
 			]
 		}`))
@@ -37,7 +46,7 @@ func TestFreeDictionaryTranslatorTranslateSuccess(t *testing.T) {
 	defer server.Close()
 
 	tr := NewFreeDictionaryTranslator(server.URL)
-	got, err := tr.Translate("behavior")
+	got, err := tr.Translate(context.Background(), "behavior")
 	if err != nil {
 		t.Fatalf("Translate returned error: %v", err)
 	}
@@ -52,18 +61,15 @@ func TestFreeDictionaryTranslatorTranslateSuccess(t *testing.T) {
 		t.Fatalf("unexpected example: %s", got.Example)
 	}
 
-	definitions, ok := got.Meaning["definitions"].([]map[string]string)
-	if !ok {
-		t.Fatalf("definitions has unexpected type: %T", got.Meaning["definitions"])
-	}
-	if len(definitions) != 1 {
-		t.Fatalf("unexpected definitions count: %d", len(definitions))
-	}
-	if definitions[0]["text"] != "The way a living creature behaves." {
-		t.Fatalf("unexpected definition text: %s", definitions[0]["text"])
-	}
-	if definitions[0]["pos"] != "noun" {
-		t.Fatalf("unexpected partOfSpeech: %s", definitions[0]["pos"])
+	want := []meaning.Sense{{
+		POS:         "noun",
+		Definitions: []string{"The way a living creature behaves."},
+		Examples:    []string{"Her behavior changed over time."},
+		Synonyms:    []string{"conduct"},
+		Antonyms:    []string{"misbehavior"},
+	}}
+	if !reflect.DeepEqual(got.Meaning.Senses, want) {
+		t.Fatalf("unexpected senses: %+v", got.Meaning.Senses)
 	}
 }
 
@@ -77,7 +83,7 @@ func TestFreeDictionaryTranslatorTranslateNotFound(t *testing.T) {
 	defer server.Close()
 
 	tr := NewFreeDictionaryTranslator(server.URL)
-	_, err := tr.Translate("behavior")
+	_, err := tr.Translate(context.Background(), "behavior")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -105,7 +111,7 @@ func TestFreeDictionaryTranslatorTranslateNoDefinitions(t *testing.T) {
 	defer server.Close()
 
 	tr := NewFreeDictionaryTranslator(server.URL)
-	_, err := tr.Translate("behavior")
+	_, err := tr.Translate(context.Background(), "behavior")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -113,3 +119,71 @@ func TestFreeDictionaryTranslatorTranslateNoDefinitions(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestFreeDictionaryTranslatorRetriesOnTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`{"word":"behavior","entries":[{"partOfSpeech":"noun","senses":[{"definition":"conduct"}]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	tr := NewFreeDictionaryTranslator(server.URL)
+	tr.retry.baseDelay = time.Millisecond
+	if _, err := tr.Translate(context.Background(), "behavior"); err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestFreeDictionaryTranslatorGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tr := NewFreeDictionaryTranslator(server.URL, WithMaxRetries(2))
+	tr.retry.baseDelay = time.Millisecond
+	_, err := tr.Translate(context.Background(), "behavior")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 StatusError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"Mon, 01 Jan 2024 00:00:30 GMT": 30 * time.Second,
+		"Sun, 31 Dec 2023 23:59:00 GMT": 0,
+		"soon":                          0,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Fatalf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package translator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// indexVersion 索引文件格式版本，格式变化时递增以触发重建
+	indexVersion = "v1"
+	// indexBlockSize 每隔多少行在内存中保留一个采样点
+	indexBlockSize = 128
+	// indexSuffix 索引文件后缀，与源文件放在同一目录
+	indexSuffix = ".vidx"
+)
+
+var errStaleIndex = errors.New("index is stale")
+
+// indexEntry 索引项：规范化单词在源文件中的字节区间
+type indexEntry struct {
+	key    string
+	offset int64
+	size   int64
+}
+
+type sparsePoint struct {
+	key string
+	pos int64 // 该行在索引文件中的偏移
+}
+
+// diskIndex 磁盘上按单词排序的索引文件，内存中只保留稀疏采样点。
+// 文件首行为 "<版本> <源文件标识>"，其后每行为 "单词\t偏移\t长度"。
+type diskIndex struct {
+	f      *os.File
+	sparse []sparsePoint
+	end    int64
+}
+
+// normalizeKey 索引键：小写并合并空白
+func normalizeKey(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
+
+// openDiskIndex 打开与 source 对应的索引，不存在或已过期时调用 build 重新生成
+func openDiskIndex(source string, build func() ([]indexEntry, error)) (*diskIndex, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	stamp := fmt.Sprintf("%s %d %d", indexVersion, info.Size(), info.ModTime().UnixNano())
+	path := source + indexSuffix
+
+	idx, err := loadDiskIndex(path, stamp)
+	if err == nil {
+		return idx, nil
+	}
+	if !errors.Is(err, errStaleIndex) && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	entries, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeDiskIndex(path, stamp, entries); err != nil {
+		return nil, fmt.Errorf("failed to write index: %w", err)
+	}
+	return loadDiskIndex(path, stamp)
+}
+
+// writeDiskIndex 排序后写入索引文件，先写临时文件再原子替换
+func writeDiskIndex(path, stamp string, entries []indexEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".vidx-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	fmt.Fprintln(w, stamp)
+	var last string
+	for i, e := range entries {
+		if e.key == "" || strings.ContainsAny(e.key, "\t\n") || (i > 0 && e.key == last) {
+			continue // 重复的键保留先出现的一项
+		}
+		last = e.key
+		fmt.Fprintf(w, "%s\t%d\t%d\n", e.key, e.offset, e.size)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadDiskIndex 打开索引文件并建立稀疏采样
+func loadDiskIndex(path, stamp string) (*diskIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(f)
+	header, err := r.ReadString('\n')
+	if err != nil || strings.TrimSpace(header) != stamp {
+		f.Close()
+		return nil, errStaleIndex
+	}
+
+	idx := &diskIndex{f: f}
+	pos := int64(len(header))
+	for n := 0; ; n++ {
+		line, err := r.ReadString('\n')
+		if len(line) > 0 && n%indexBlockSize == 0 {
+			key, _, _ := strings.Cut(line, "\t")
+			idx.sparse = append(idx.sparse, sparsePoint{key: key, pos: pos})
+		}
+		pos += int64(len(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	idx.end = pos
+	return idx, nil
+}
+
+// lookup 查找规范化单词在源文件中的区间
+func (idx *diskIndex) lookup(key string) (indexEntry, bool, error) {
+	i := sort.Search(len(idx.sparse), func(i int) bool { return idx.sparse[i].key > key }) - 1
+	if i < 0 {
+		return indexEntry{}, false, nil
+	}
+	start, end := idx.sparse[i].pos, idx.end
+	if i+1 < len(idx.sparse) {
+		end = idx.sparse[i+1].pos
+	}
+
+	block := make([]byte, end-start)
+	if _, err := idx.f.ReadAt(block, start); err != nil && err != io.EOF {
+		return indexEntry{}, false, err
+	}
+	for _, line := range bytes.Split(block, []byte{'\n'}) {
+		fields := strings.Split(string(line), "\t")
+		if len(fields) != 3 || fields[0] != key {
+			continue
+		}
+		offset, err1 := strconv.ParseInt(fields[1], 10, 64)
+		size, err2 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			return indexEntry{}, false, fmt.Errorf("corrupted index line: %q", line)
+		}
+		return indexEntry{key: key, offset: offset, size: size}, true, nil
+	}
+	return indexEntry{}, false, nil
+}
+
+// Close 关闭索引文件
+func (idx *diskIndex) Close() error {
+	return idx.f.Close()
+}
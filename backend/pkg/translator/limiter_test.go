@@ -0,0 +1,43 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllowsBurstThenWaits(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(50, 2)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Fatalf("third request should wait for a token, elapsed %v", elapsed)
+	}
+}
+
+func TestLimiterWaitHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	var unlimited *Limiter
+	if err := unlimited.Wait(context.Background()); err != nil {
+		t.Fatalf("nil limiter should not block: %v", err)
+	}
+}
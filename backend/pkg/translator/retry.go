@@ -0,0 +1,99 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 500 * time.Millisecond
+	// maxRetryDelay 单次重试的最长等待，Retry-After 超过该值时不再重试
+	maxRetryDelay = 30 * time.Second
+)
+
+// StatusError 提供方返回的非预期 HTTP 状态
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	RetryAfter time.Duration // 响应携带的 Retry-After，未携带时为 0
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s api status: %d", e.Provider, e.StatusCode)
+}
+
+// Retryable 限流（429）与服务端错误（5xx）可以重试
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// retryPolicy 指数退避重试策略
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// do 调用 fn，遇到可重试的 StatusError 时按指数退避（带抖动）重试；
+// 响应带 Retry-After 时至少等待该时长。每次尝试前都会先等待限流器。
+func (p retryPolicy) do(ctx context.Context, limiter *Limiter, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		err := fn()
+		var statusErr *StatusError
+		if err == nil || !errors.As(err, &statusErr) || !statusErr.Retryable() || attempt >= p.maxRetries {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		if statusErr.RetryAfter > delay {
+			delay = statusErr.RetryAfter
+		}
+		if delay > maxRetryDelay {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// backoff 第 attempt 次重试前的等待：baseDelay·2^attempt，在其一半到全部之间随机
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.baseDelay << attempt
+	if d <= 0 || d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter 解析 Retry-After 头，支持秒数与 HTTP 日期两种格式
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+package translator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"backend/pkg/meaning"
+)
+
+// ProviderStarDict 本地 StarDict 词典的提供方名称
+const ProviderStarDict = "stardict"
+
+var (
+	htmlBreak = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	htmlTag   = regexp.MustCompile(`<[^>]+>`)
+	// stardictPhonetic 释义首行的音标，如 "*[ə'bændən]" 或 "/ə'bændən/"
+	stardictPhonetic = regexp.MustCompile(`^\*?(\[[^\]]+\]|/[^/]+/)$`)
+)
+
+// StarDictTranslator 基于本地 StarDict 词典（.ifo/.idx/.dict）的离线词典。
+// 不支持压缩的 .dict.dz 与 .idx.gz，请先解压。
+type StarDictTranslator struct {
+	dict             *os.File
+	index            *diskIndex
+	sameTypeSequence string
+}
+
+// NewStarDictTranslator 根据 .ifo 文件打开同名的 .idx 与 .dict，首次加载时生成 .vidx 索引
+func NewStarDictTranslator(ifoPath string) (*StarDictTranslator, error) {
+	info, err := readStarDictInfo(ifoPath)
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(ifoPath, ".ifo")
+	offsetBits := 32
+	if info["idxoffsetbits"] == "64" {
+		offsetBits = 64
+	}
+
+	dict, err := os.Open(base + ".dict")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stardict data: %w", err)
+	}
+	idxPath := base + ".idx"
+	index, err := openDiskIndex(idxPath, func() ([]indexEntry, error) {
+		return readStarDictIdx(idxPath, offsetBits)
+	})
+	if err != nil {
+		dict.Close()
+		return nil, fmt.Errorf("failed to index stardict: %w", err)
+	}
+	return &StarDictTranslator{dict: dict, index: index, sameTypeSequence: info["sametypesequence"]}, nil
+}
+
+// readStarDictInfo 解析 .ifo 的 key=value 行
+func readStarDictInfo(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stardict info: %w", err)
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "StarDict's dict ifo file") {
+		return nil, fmt.Errorf("not a stardict ifo file: %s", path)
+	}
+	info := make(map[string]string)
+	for _, line := range lines[1:] {
+		if k, v, ok := strings.Cut(line, "="); ok {
+			info[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return info, nil
+}
+
+// readStarDictIdx 读取 .idx：每项为 "单词\0" + 偏移（32/64 位大端）+ 长度（32 位大端）
+func readStarDictIdx(path string, offsetBits int) ([]indexEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var entries []indexEntry
+	for {
+		word, err := r.ReadString(0)
+		if err == io.EOF && word == "" {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("truncated stardict idx: %w", err)
+		}
+		var offset int64
+		if offsetBits == 64 {
+			var v uint64
+			err = binary.Read(r, binary.BigEndian, &v)
+			offset = int64(v)
+		} else {
+			var v uint32
+			err = binary.Read(r, binary.BigEndian, &v)
+			offset = int64(v)
+		}
+		var size uint32
+		if err == nil {
+			err = binary.Read(r, binary.BigEndian, &size)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("truncated stardict idx: %w", err)
+		}
+		entries = append(entries, indexEntry{key: normalizeKey(strings.TrimSuffix(word, "\x00")), offset: offset, size: int64(size)})
+	}
+}
+
+// Translate 查询本地词典
+func (t *StarDictTranslator) Translate(ctx context.Context, word string) (*WordDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := normalizeKey(word)
+	if key == "" {
+		return nil, fmt.Errorf("word is empty")
+	}
+	entry, ok, err := t.index.lookup(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup stardict: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+
+	buf := make([]byte, entry.size)
+	if _, err := t.dict.ReadAt(buf, entry.offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read stardict data: %w", err)
+	}
+
+	detail := &WordDetail{Word: key, Provider: ProviderStarDict}
+	var lines []string
+	for _, f := range splitStarDictFields(buf, t.sameTypeSequence) {
+		switch f.kind {
+		case 't':
+			detail.Phonetic = "/" + strings.Trim(strings.TrimSpace(f.text), "/[]") + "/"
+		case 'h', 'x', 'g':
+			lines = append(lines, strings.Split(stripMarkup(f.text), "\n")...)
+		case 'm', 'l', 'y', 'k', 'w':
+			lines = append(lines, strings.Split(f.text, "\n")...)
+		}
+	}
+
+	var glosses []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := stardictPhonetic.FindStringSubmatch(line); m != nil && detail.Phonetic == "" {
+			detail.Phonetic = "/" + strings.Trim(m[1], "/[]") + "/"
+			continue
+		}
+		glosses = append(glosses, line)
+	}
+	if len(glosses) == 0 {
+		return nil, fmt.Errorf("%w: no definitions found for word: %s", ErrNotFound, key)
+	}
+	detail.Meaning = meaning.ParseGlosses(strings.Join(glosses, "\n"))
+	return detail, nil
+}
+
+// Close 关闭词典文件
+func (t *StarDictTranslator) Close() error {
+	t.index.Close()
+	return t.dict.Close()
+}
+
+type starDictField struct {
+	kind byte
+	text string
+}
+
+// splitStarDictFields 拆分词条数据：设置了 sametypesequence 时类型由其给出且省略最后一项的结束符，
+// 否则每项以类型字节开头；小写类型以 \0 结尾，大写类型带 32 位长度前缀（非文本，跳过）
+func splitStarDictFields(data []byte, sameTypeSequence string) []starDictField {
+	var fields []starDictField
+	next := func(kind byte, last bool) bool {
+		if kind >= 'A' && kind <= 'Z' {
+			if last {
+				data = nil
+				return true
+			}
+			if len(data) < 4 {
+				return false
+			}
+			size := int(binary.BigEndian.Uint32(data))
+			if size > len(data)-4 {
+				return false
+			}
+			data = data[4+size:]
+			return true
+		}
+		text := data
+		if i := bytes.IndexByte(data, 0); i >= 0 {
+			text, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		fields = append(fields, starDictField{kind: kind, text: string(text)})
+		return true
+	}
+
+	if sameTypeSequence != "" {
+		for i := 0; i < len(sameTypeSequence) && len(data) > 0; i++ {
+			if !next(sameTypeSequence[i], i == len(sameTypeSequence)-1) {
+				break
+			}
+		}
+		return fields
+	}
+	for len(data) > 0 {
+		kind := data[0]
+		data = data[1:]
+		if !next(kind, false) {
+			break
+		}
+	}
+	return fields
+}
+
+// stripMarkup 去除 HTML/XDXF 标记，保留换行
+func stripMarkup(s string) string {
+	s = htmlBreak.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, "")
+	replacer := strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&", "&quot;", `"`, "&#39;", "'", "&nbsp;", " ")
+	return replacer.Replace(s)
+}
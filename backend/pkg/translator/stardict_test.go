@@ -0,0 +1,57 @@
+package translator
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStarDictTranslatorTranslate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "langdao-ec")
+	entries := []struct{ word, data string }{
+		{"abandon", "*[ə'bændən]\nvt. 放弃；抛弃\nn. 放任"},
+		{"apple", "n. 苹果"},
+	}
+
+	var dict, idx []byte
+	for _, e := range entries {
+		idx = append(idx, e.word...)
+		idx = append(idx, 0)
+		idx = binary.BigEndian.AppendUint32(idx, uint32(len(dict)))
+		idx = binary.BigEndian.AppendUint32(idx, uint32(len(e.data)))
+		dict = append(dict, e.data...)
+	}
+	ifo := "StarDict's dict ifo file\nversion=2.4.2\nwordcount=2\nbookname=test\nsametypesequence=m\n"
+	for name, content := range map[string][]byte{".ifo": []byte(ifo), ".idx": idx, ".dict": dict} {
+		if err := os.WriteFile(base+name, content, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	tr, err := NewStarDictTranslator(base + ".ifo")
+	if err != nil {
+		t.Fatalf("NewStarDictTranslator returned error: %v", err)
+	}
+	defer tr.Close()
+
+	got, err := tr.Translate(context.Background(), "Abandon")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if got.Phonetic != "/ə'bændən/" || got.Provider != ProviderStarDict {
+		t.Fatalf("unexpected detail: %+v", got)
+	}
+	senses := got.Meaning.Senses
+	if len(senses) != 2 || senses[0].POS != "vt." || senses[1].Translations[0] != "放任" {
+		t.Fatalf("unexpected senses: %+v", senses)
+	}
+	if _, err := tr.Translate(context.Background(), "banana"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
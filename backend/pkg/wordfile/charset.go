@@ -0,0 +1,185 @@
+// pkg/wordfile/charset.go
+package wordfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// 检测到的字符集名称
+const (
+	CharsetUTF8    = "utf-8"
+	CharsetUTF16LE = "utf-16le"
+	CharsetUTF16BE = "utf-16be"
+	CharsetGB18030 = "gb18030"
+)
+
+// sniffLen 用于字符集检测的文件头长度
+const sniffLen = 4096
+
+var bomUTF8 = []byte{0xEF, 0xBB, 0xBF}
+
+// NewReader 检测输入的字符集并返回转换为 UTF-8 的 Reader，不会一次性读入全部内容。
+// 依次识别 UTF-8/UTF-16 BOM、无 BOM 的 UTF-16，其余非法 UTF-8 内容按 GB18030（兼容 GBK）解码。
+// 文件头全是 ASCII 时，在第一个非 ASCII 字符处按其后一段内容重新判断，必要时其余内容改按 GB18030 解码；
+// 已按 UTF-8 读取后再出现非法内容时，读取返回带行号的 *EncodingError。
+func NewReader(r io.Reader) (io.Reader, string, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, "", err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		br.Discard(len(bomUTF8))
+		return &utf8Reader{r: br, line: 1}, CharsetUTF8, nil
+	case bytes.HasPrefix(head, []byte{0xFF, 0xFE}):
+		return utf16Reader(br, unicode.LittleEndian, unicode.ExpectBOM), CharsetUTF16LE, nil
+	case bytes.HasPrefix(head, []byte{0xFE, 0xFF}):
+		return utf16Reader(br, unicode.BigEndian, unicode.ExpectBOM), CharsetUTF16BE, nil
+	}
+
+	switch sniffUTF16(head) {
+	case CharsetUTF16LE:
+		return utf16Reader(br, unicode.LittleEndian, unicode.IgnoreBOM), CharsetUTF16LE, nil
+	case CharsetUTF16BE:
+		return utf16Reader(br, unicode.BigEndian, unicode.IgnoreBOM), CharsetUTF16BE, nil
+	}
+
+	if validUTF8Prefix(head, len(head) == sniffLen) {
+		return &utf8Reader{r: br, line: 1, ascii: true}, CharsetUTF8, nil
+	}
+	return transform.NewReader(br, simplifiedchinese.GB18030.NewDecoder()), CharsetGB18030, nil
+}
+
+// EncodingError 按 UTF-8 读取的文件中出现了无法解码的内容
+type EncodingError struct {
+	Line int // 出错的行号，从 1 开始
+}
+
+func (e *EncodingError) Error() string {
+	return fmt.Sprintf("invalid utf-8 at line %d", e.Line)
+}
+
+// utf8Reader 逐段校验 UTF-8 内容后原样输出。GB18030 与 ASCII 兼容，
+// 因此在第一个非 ASCII 字符之前都可以改为按 GB18030 解码其余内容。
+type utf8Reader struct {
+	r     *bufio.Reader
+	line  int       // 下一个待校验字节所在的行号
+	ascii bool      // 已校验的内容全为 ASCII，仍可切换为 GB18030
+	valid int       // 已校验、尚未输出的字节数
+	gb    io.Reader // 切换后的 GB18030 解码器
+}
+
+func (u *utf8Reader) Read(p []byte) (int, error) {
+	if u.gb == nil && u.valid == 0 {
+		if err := u.validate(); err != nil {
+			return 0, err
+		}
+	}
+	if u.gb != nil {
+		return u.gb.Read(p)
+	}
+	n, err := u.r.Read(p[:min(len(p), u.valid)])
+	u.valid -= n
+	return n, err
+}
+
+// validate 校验缓冲区中的下一段内容，遇到非 ASCII 或非法 UTF-8 时先交出此前的部分
+func (u *utf8Reader) validate() error {
+	buf, err := u.r.Peek(max(u.r.Buffered(), utf8.UTFMax))
+	if len(buf) == 0 {
+		return err
+	}
+	n := 0
+	for n < len(buf) {
+		if c := buf[n]; c < utf8.RuneSelf {
+			if c == '\n' {
+				u.line++
+			}
+			n++
+			continue
+		}
+		// 先交出此前的内容，使下面的判断总是从缓冲区开头进行
+		if n > 0 && (u.ascii || !utf8.FullRune(buf[n:])) {
+			break
+		}
+		if u.ascii {
+			// 第一个非 ASCII 字节：与文件头一样按其后一段内容判断，GBK 双字节偶尔也是合法的 UTF-8
+			head, err := u.r.Peek(sniffLen)
+			if err != nil && err != io.EOF {
+				return err
+			}
+			if !validUTF8Prefix(head, len(head) == sniffLen) {
+				u.gb = transform.NewReader(u.r, simplifiedchinese.GB18030.NewDecoder())
+				return nil
+			}
+			buf = head // Peek 可能移动了缓冲区
+			u.ascii = false
+		}
+		r, size := utf8.DecodeRune(buf[n:])
+		if r == utf8.RuneError && size <= 1 {
+			if n > 0 {
+				break
+			}
+			return &EncodingError{Line: u.line}
+		}
+		n += size
+	}
+	u.valid = n
+	return nil
+}
+
+func utf16Reader(r io.Reader, endian unicode.Endianness, bom unicode.BOMPolicy) io.Reader {
+	return transform.NewReader(r, unicode.UTF16(endian, bom).NewDecoder())
+}
+
+// sniffUTF16 根据零字节的分布判断无 BOM 的 UTF-16：英文单词表中每个字符的高字节几乎都是 0
+func sniffUTF16(head []byte) string {
+	if len(head) < 4 {
+		return ""
+	}
+	var even, odd int
+	for i, b := range head {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			even++
+		} else {
+			odd++
+		}
+	}
+	half := len(head) / 2
+	switch {
+	case odd > half*2/5 && even < half/10:
+		return CharsetUTF16LE
+	case even > half*2/5 && odd < half/10:
+		return CharsetUTF16BE
+	default:
+		return ""
+	}
+}
+
+// validUTF8Prefix 判断文件头是否为合法 UTF-8；truncated 为 true 时忽略末尾被截断的不完整字符
+func validUTF8Prefix(head []byte, truncated bool) bool {
+	if truncated {
+		for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(head[i]) {
+				if !utf8.FullRune(head[i:]) {
+					head = head[:i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(head)
+}
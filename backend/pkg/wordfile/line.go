@@ -0,0 +1,129 @@
+// pkg/wordfile/line.go
+package wordfile
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// LineKind 单行解析结果类型
+type LineKind int
+
+const (
+	// LineSkip 空行或注释
+	LineSkip LineKind = iota
+	// LineWord 识别出单词（及可选释义）
+	LineWord
+	// LineInvalid 无法识别为英文单词或词组
+	LineInvalid
+)
+
+const (
+	// maxWordLength 单词或词组的最大字符数
+	maxWordLength = 64
+	// maxPhraseWords 词组最多包含的单词数，超过视为句子
+	maxPhraseWords = 5
+)
+
+// Entry 单行解析出的单词与释义
+type Entry struct {
+	Word    string
+	Meaning string // 文件中附带的释义，可能为空
+}
+
+var (
+	// listMarker 行首的编号或列表符号：1. / 1) / 1、/ (1) / 1 / - / * / •
+	listMarker = regexp.MustCompile(`^(?:\(?\d+\s*[.)、:：]|\(\d+\)|\d+\s+|[-*•·])\s*`)
+	// wordHead 行首的英文单词或词组，词组内单词以单个空格分隔
+	wordHead = regexp.MustCompile(`^[A-Za-z][A-Za-z'’-]*(?: [A-Za-z][A-Za-z'’-]*)*`)
+	// validWord 完整的合法单词或词组
+	validWord = regexp.MustCompile(`^[A-Za-z]+(?:['’-][A-Za-z]+)*(?: [A-Za-z]+(?:['’-][A-Za-z]+)*)*$`)
+	// posAbbrev 常见词性缩写，形如 "apple n. 苹果" 时不应并入词组
+	posAbbrev = map[string]bool{
+		"n": true, "v": true, "vt": true, "vi": true, "a": true, "adj": true, "adv": true,
+		"prep": true, "conj": true, "pron": true, "num": true, "art": true, "int": true, "interj": true, "aux": true,
+	}
+)
+
+// IsComment 判断是否为注释行（# 或 // 开头）
+func IsComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//")
+}
+
+// ValidWord 判断是否为合法的英文单词或词组（字母，可含撇号、连字符，最多 5 个单词）
+func ValidWord(word string) bool {
+	word = strings.Join(strings.Fields(word), " ")
+	if word == "" || utf8.RuneCountInString(word) > maxWordLength {
+		return false
+	}
+	return validWord.MatchString(word) && strings.Count(word, " ") < maxPhraseWords
+}
+
+// ParseLine 解析单词文件中的一行，支持以下格式：
+//
+//	apple
+//	1. apple  苹果
+//	- apple: 苹果
+//	apple n. 苹果
+//	give up	放弃
+//	apple # 行尾注释
+func ParseLine(line string) (Entry, LineKind) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "\uFEFF"))
+	if line == "" || IsComment(line) {
+		return Entry{}, LineSkip
+	}
+	if strings.Contains(line, "://") {
+		return Entry{}, LineInvalid
+	}
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	line = listMarker.ReplaceAllString(line, "")
+	line = strings.Trim(line, "\"“”'")
+
+	head := wordHead.FindString(line)
+	if head == "" {
+		return Entry{}, LineInvalid
+	}
+	rest := line[len(head):]
+
+	// "apple n. 苹果"：词组末尾的词性缩写归入释义
+	if i := strings.LastIndexByte(head, ' '); i >= 0 && posAbbrev[strings.ToLower(head[i+1:])] && strings.HasPrefix(rest, ".") {
+		rest = " " + head[i+1:] + rest
+		head = head[:i]
+	}
+	if rest != "" && !startsWithSeparator(rest) {
+		// 如 "apple123"、"apple苹果" 之外的字母数字混排
+		return Entry{}, LineInvalid
+	}
+	if !ValidWord(head) {
+		return Entry{}, LineInvalid
+	}
+	return Entry{Word: head, Meaning: cleanMeaning(rest)}, LineWord
+}
+
+// startsWithSeparator 单词之后必须是空白、常见分隔符或非 ASCII 字符（如直接跟中文释义）
+func startsWithSeparator(rest string) bool {
+	r, _ := utf8.DecodeRuneInString(rest)
+	if r >= utf8.RuneSelf {
+		return true
+	}
+	return strings.ContainsRune(" \t,;:=|/[(.!?", r)
+}
+
+// cleanMeaning 去除释义前的分隔符与音标，只保留释义文本
+func cleanMeaning(rest string) string {
+	rest = strings.TrimLeft(rest, " \t,;:=|-–—，；：、.!?")
+	rest = strings.TrimSpace(rest)
+	// 去除开头的音标 [ˈæpl] 或 /ˈæpl/
+	for _, pair := range [][2]string{{"[", "]"}, {"/", "/"}} {
+		if strings.HasPrefix(rest, pair[0]) {
+			if i := strings.Index(rest[1:], pair[1]); i >= 0 {
+				rest = strings.TrimSpace(rest[i+2:])
+			}
+		}
+	}
+	return strings.TrimLeft(rest, " \t,;:=|-–—，；：、")
+}
@@ -0,0 +1,119 @@
+// pkg/wordfile/wordfile_test.go
+package wordfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		kind    LineKind
+		word    string
+		meaning string
+	}{
+		{"apple", LineWord, "apple", ""},
+		{"  ", LineSkip, "", ""},
+		{"# Unit 1", LineSkip, "", ""},
+		{"// comment", LineSkip, "", ""},
+		{"1. apple  苹果", LineWord, "apple", "苹果"},
+		{"2) banana", LineWord, "banana", ""},
+		{"3、cherry：樱桃", LineWord, "cherry", "樱桃"},
+		{"(4) date", LineWord, "date", ""},
+		{"- give up: 放弃", LineWord, "give up", "放弃"},
+		{"give up\t放弃", LineWord, "give up", "放弃"},
+		{"apple n. 苹果", LineWord, "apple", "n. 苹果"},
+		{"apple [ˈæpl] n. 苹果", LineWord, "apple", "n. 苹果"},
+		{"apple /ˈæpl/ 苹果", LineWord, "apple", "苹果"},
+		{"apple苹果", LineWord, "apple", "苹果"},
+		{"apple = a fruit", LineWord, "apple", "a fruit"},
+		{"well-known # 常用", LineWord, "well-known", ""},
+		{"don't", LineWord, "don't", ""},
+		{"\"apple\"", LineWord, "apple", ""},
+		{"苹果", LineInvalid, "", ""},
+		{"apple123", LineInvalid, "", ""},
+		{"12345", LineInvalid, "", ""},
+		{"https://example.com", LineInvalid, "", ""},
+		{"this is a very long sentence with many words", LineInvalid, "", ""},
+	}
+	for _, tt := range tests {
+		entry, kind := ParseLine(tt.line)
+		if kind != tt.kind || entry.Word != tt.word || entry.Meaning != tt.meaning {
+			t.Errorf("ParseLine(%q) = (%+v, %d), want (%q, %q, %d)", tt.line, entry, kind, tt.word, tt.meaning, tt.kind)
+		}
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	const text = "apple\n苹果\nbanana\n"
+	gbk, _ := simplifiedchinese.GBK.NewEncoder().String(text)
+	utf16le, _ := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(text)
+	utf16be, _ := unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder().String(text)
+	utf16leNoBOM, _ := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().String("apple\nbanana\ncherry\n")
+
+	tests := []struct {
+		name    string
+		input   []byte
+		charset string
+		want    string
+	}{
+		{"utf8", []byte(text), CharsetUTF8, text},
+		{"utf8 bom", append([]byte{0xEF, 0xBB, 0xBF}, text...), CharsetUTF8, text},
+		{"gbk", []byte(gbk), CharsetGB18030, text},
+		{"utf16le bom", []byte(utf16le), CharsetUTF16LE, text},
+		{"utf16be bom", []byte(utf16be), CharsetUTF16BE, text},
+		{"utf16le", []byte(utf16leNoBOM), CharsetUTF16LE, "apple\nbanana\ncherry\n"},
+	}
+	for _, tt := range tests {
+		r, charset, err := NewReader(bytes.NewReader(tt.input))
+		if err != nil {
+			t.Fatalf("%s: NewReader error: %v", tt.name, err)
+		}
+		got, _ := io.ReadAll(r)
+		if charset != tt.charset || string(got) != tt.want {
+			t.Errorf("%s: got (%q, %q), want (%q, %q)", tt.name, charset, got, tt.charset, tt.want)
+		}
+	}
+}
+
+func TestNewReaderLargeUTF8(t *testing.T) {
+	// 多字节字符跨越检测窗口边界时仍应识别为 UTF-8
+	text := strings.Repeat("a", sniffLen-1) + "苹果\n"
+	_, charset, err := NewReader(strings.NewReader(text))
+	if err != nil || charset != CharsetUTF8 {
+		t.Errorf("got (%q, %v), want utf-8", charset, err)
+	}
+}
+
+func TestNewReaderGBKAfterSniff(t *testing.T) {
+	// 文件头全是 ASCII，检测窗口之后才出现 GBK 内容时应改按 GB18030 解码（“苹”的 GBK 编码恰好也是合法的 UTF-8）
+	gbk, _ := simplifiedchinese.GBK.NewEncoder().String("apple 苹果\n")
+	head := strings.Repeat("word\n", sniffLen/5+10)
+	r, _, err := NewReader(strings.NewReader(head + gbk))
+	if err != nil {
+		t.Fatalf("NewReader error: %v", err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != head+"apple 苹果\n" {
+		t.Errorf("got (%q, %v), want GB18030 decoded tail", got[len(got)-min(len(got), 20):], err)
+	}
+
+	// 此前已有 UTF-8 中文时无法切换，返回出错的行号
+	text := "苹果\n" + head + string([]byte{0xB9, 0xFB}) + "\n"
+	r, charset, err := NewReader(strings.NewReader(text))
+	if err != nil || charset != CharsetUTF8 {
+		t.Fatalf("got (%q, %v), want utf-8", charset, err)
+	}
+	_, err = io.ReadAll(r)
+	var encErr *EncodingError
+	if !errors.As(err, &encErr) || encErr.Line != sniffLen/5+12 {
+		t.Errorf("got %v, want EncodingError at line %d", err, sniffLen/5+12)
+	}
+}
@@ -0,0 +1,126 @@
+// pkg/wordlist/wordlist.go
+package wordlist
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+
+	"backend/pkg/meaning"
+)
+
+//go:embed data/*.tsv
+var dataFS embed.FS
+
+// Info 内置词表信息
+type Info struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	file        string
+}
+
+// Definition 单条释义
+type Definition struct {
+	POS  string `json:"pos"`  // 词性，如 "n."、"v."
+	Text string `json:"text"` // 中文释义
+}
+
+// Entry 词表中的单词，释义已预先整理，导入时无需调用翻译 API
+type Entry struct {
+	Word        string
+	Phonetic    string
+	Definitions []Definition
+}
+
+// Meaning 转换为与翻译结果一致的释义结构，词表释义均为中文翻译
+func (e *Entry) Meaning() meaning.Meaning {
+	var m meaning.Meaning
+	for _, d := range e.Definitions {
+		m.AddTranslation(d.POS, d.Text)
+	}
+	return m
+}
+
+// catalog 内置词表目录，顺序即展示顺序
+var catalog = []Info{
+	{ID: "cet4", Name: "大学英语四级 (CET-4)", Description: "大学英语四级考试核心词汇", file: "data/cet4.tsv"},
+	{ID: "cet6", Name: "大学英语六级 (CET-6)", Description: "大学英语六级考试核心词汇", file: "data/cet6.tsv"},
+	{ID: "ielts", Name: "雅思 (IELTS)", Description: "雅思考试核心词汇", file: "data/ielts.tsv"},
+	{ID: "toefl", Name: "托福 (TOEFL)", Description: "托福考试核心词汇", file: "data/toefl.tsv"},
+	{ID: "gre", Name: "GRE", Description: "GRE 考试核心词汇", file: "data/gre.tsv"},
+}
+
+// List 返回全部内置词表
+func List() []Info {
+	return append([]Info(nil), catalog...)
+}
+
+// Get 根据 ID 获取词表信息
+func Get(id string) (Info, bool) {
+	for _, info := range catalog {
+		if info.ID == id {
+			return info, true
+		}
+	}
+	return Info{}, false
+}
+
+// Load 读取词表全部单词
+func Load(id string) ([]*Entry, error) {
+	info, ok := Get(id)
+	if !ok {
+		return nil, fmt.Errorf("wordlist %q not found", id)
+	}
+	data, err := dataFS.ReadFile(info.file)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
+}
+
+// parse 解析 TSV 词表：单词<TAB>音标<TAB>释义，释义以 " | " 分隔，# 开头为注释
+func parse(data []byte) ([]*Entry, error) {
+	var entries []*Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		if len(cols) != 3 || strings.TrimSpace(cols[0]) == "" {
+			return nil, fmt.Errorf("line %d: expected 3 tab-separated columns", lineNo)
+		}
+		entry := &Entry{
+			Word:     strings.TrimSpace(cols[0]),
+			Phonetic: strings.TrimSpace(cols[1]),
+		}
+		for _, sense := range strings.Split(cols[2], "|") {
+			if def, ok := parseDefinition(sense); ok {
+				entry.Definitions = append(entry.Definitions, def)
+			}
+		}
+		if len(entry.Definitions) == 0 {
+			return nil, fmt.Errorf("line %d: missing definition for %q", lineNo, entry.Word)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// parseDefinition 解析 "v. 放弃；抛弃"，没有词性前缀时整段作为释义
+func parseDefinition(s string) (Definition, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Definition{}, false
+	}
+	if i := strings.Index(s, ". "); i > 0 && i <= 5 && !strings.ContainsAny(s[:i], " \t") {
+		return Definition{POS: s[:i+1], Text: strings.TrimSpace(s[i+2:])}, true
+	}
+	return Definition{Text: s}, true
+}
@@ -0,0 +1,63 @@
+package wordlist
+
+import (
+	"testing"
+)
+
+func TestLoadAllBuiltinLists(t *testing.T) {
+	for _, info := range List() {
+		entries, err := Load(info.ID)
+		if err != nil {
+			t.Fatalf("Load(%q) returned error: %v", info.ID, err)
+		}
+		if len(entries) == 0 {
+			t.Fatalf("Load(%q) returned no entries", info.ID)
+		}
+		seen := make(map[string]bool, len(entries))
+		for _, e := range entries {
+			if seen[e.Word] {
+				t.Fatalf("%s: duplicate word %q", info.ID, e.Word)
+			}
+			seen[e.Word] = true
+			if e.Phonetic == "" {
+				t.Fatalf("%s: word %q has no phonetic", info.ID, e.Word)
+			}
+		}
+	}
+}
+
+func TestLoadUnknownList(t *testing.T) {
+	if _, err := Load("unknown"); err == nil {
+		t.Fatal("expected error for unknown list")
+	}
+}
+
+func TestParseDefinitions(t *testing.T) {
+	entries, err := parse([]byte("# comment\nabandon\t/əˈbændən/\tv. 放弃；抛弃 | n. 放任\nfoo\t\t无词性释义\n"))
+	if err != nil {
+		t.Fatalf("parse returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("unexpected entries count: %d", len(entries))
+	}
+
+	got := entries[0].Definitions
+	want := []Definition{{POS: "v.", Text: "放弃；抛弃"}, {POS: "n.", Text: "放任"}}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected definitions: %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("definition %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if d := entries[1].Definitions[0]; d.POS != "" || d.Text != "无词性释义" {
+		t.Fatalf("unexpected definition without pos: %+v", d)
+	}
+}
+
+func TestParseRejectsMalformedLine(t *testing.T) {
+	if _, err := parse([]byte("abandon only-two-columns\n")); err == nil {
+		t.Fatal("expected error for malformed line")
+	}
+}
@@ -31,10 +31,13 @@ const WordCard: React.FC<WordCardProps> = ({ word, onReveal }) => {
         </button>
       ) : (
         <div className="word-meaning">
-          {word.meaning.definitions.length > 0 ? word.meaning.definitions.map((def, idx) => (
+          {word.meaning.senses.length > 0 ? word.meaning.senses.map((sense, idx) => (
             <div key={idx} className="definition-item">
-              <span className="pos">{def.pos}</span>
-              <span className="text">{def.text}</span>
+              {sense.pos && <span className="pos">{sense.pos}</span>}
+              <span className="text">
+                {[...sense.translations, ...sense.definitions].join('；')}
+                {sense.synonyms.length > 0 && <span className="synonyms">近义词：{sense.synonyms.join(', ')}</span>}
+              </span>
             </div>
           )) : <div className="definition-item"><span className="text">暂无释义</span></div>}
 
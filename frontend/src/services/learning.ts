@@ -1,12 +1,17 @@
 import request from './request';
 
-export interface Definition {
-  pos: string;
-  text: string;
+export interface WordSense {
+  pos?: string;
+  definitions: string[];
+  translations: string[];
+  examples: string[];
+  synonyms: string[];
+  antonyms: string[];
 }
 
 export interface WordMeaning {
-  definitions: Definition[];
+  senses: WordSense[];
+  tags: string[];
 }
 
 export interface Word {
@@ -28,11 +33,25 @@ export interface SubmitLearningData {
   quality: number;
 }
 
+interface WordSensePB {
+  pos?: string;
+  definitions?: string[];
+  translations?: string[];
+  examples?: string[];
+  synonyms?: string[];
+  antonyms?: string[];
+}
+
+interface WordMeaningPB {
+  senses?: WordSensePB[];
+  tags?: string[];
+}
+
 interface WordPB {
   id: number;
   word: string;
   phonetic?: string;
-  meaning?: string;
+  meaning?: WordMeaningPB;
   example?: string;
 }
 
@@ -42,18 +61,17 @@ interface LearningTaskPB {
   newCount: number;
 }
 
-const parseMeaning = (raw?: string): WordMeaning => {
-  if (!raw) {
-    return { definitions: [] };
-  }
-
-  try {
-    const decoded = atob(raw);
-    return JSON.parse(decoded) as WordMeaning;
-  } catch {
-    return { definitions: [] };
-  }
-};
+const parseMeaning = (raw?: WordMeaningPB): WordMeaning => ({
+  senses: (raw?.senses ?? []).map((s) => ({
+    pos: s.pos,
+    definitions: s.definitions ?? [],
+    translations: s.translations ?? [],
+    examples: s.examples ?? [],
+    synonyms: s.synonyms ?? [],
+    antonyms: s.antonyms ?? [],
+  })),
+  tags: raw?.tags ?? [],
+});
 
 export const getTodayTasks = async (params: { dict_id: number; limit?: number }): Promise<LearningTask> => {
   const data = (await request.get('/learning/today-tasks', {